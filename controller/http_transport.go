@@ -14,11 +14,38 @@ const (
 	v1Resource     = "v1"
 	healthResource = "health"
 	riddleResource = "riddle"
+	paretoResource = "pareto"
+	oracleResource = "oracle"
+	bigResource    = "big"
+	streamResource = "stream"
+
+	solutionsResource = "solutions"
+	shareResource     = "share"
+	mixResource       = "mix"
+	scheduleResource  = "schedule"
+	nearestResource   = "nearest"
+	designResource    = "design"
+	inferResource     = "infer"
+	recipeResource    = "recipe"
 )
 
 var (
 	healthEndpoint = fmt.Sprintf("/%s/%s/%s", apiResource, v1Resource, healthResource)
 	riddleEndpoint = fmt.Sprintf("/%s/%s/%s", apiResource, v1Resource, riddleResource)
+
+	riddleParetoEndpoint = fmt.Sprintf("%s/%s", riddleEndpoint, paretoResource)
+	riddleOracleEndpoint = fmt.Sprintf("%s/%s", riddleEndpoint, oracleResource)
+	riddleBigEndpoint    = fmt.Sprintf("%s/%s", riddleEndpoint, bigResource)
+	riddleStreamEndpoint = fmt.Sprintf("%s/%s", riddleEndpoint, streamResource)
+
+	riddleSolutionsEndpoint = fmt.Sprintf("%s/%s", riddleEndpoint, solutionsResource)
+	riddleShareEndpoint     = fmt.Sprintf("%s/%s", riddleEndpoint, shareResource)
+	riddleMixEndpoint       = fmt.Sprintf("%s/%s", riddleEndpoint, mixResource)
+	riddleScheduleEndpoint  = fmt.Sprintf("%s/%s", riddleEndpoint, scheduleResource)
+	riddleNearestEndpoint   = fmt.Sprintf("%s/%s", riddleEndpoint, nearestResource)
+	riddleDesignEndpoint    = fmt.Sprintf("%s/%s", riddleEndpoint, designResource)
+	riddleInferEndpoint     = fmt.Sprintf("%s/%s", riddleEndpoint, inferResource)
+	riddleRecipeEndpoint    = fmt.Sprintf("%s/%s", riddleEndpoint, recipeResource)
 )
 
 // NewHandler: create handlers
@@ -30,6 +57,19 @@ func NewHandler(svc service.Service) http.Handler {
 
 		r.Get(healthEndpoint, health(svc))
 		r.Get(riddleEndpoint, riddle(svc))
+		r.Post(riddleEndpoint, solveRiddle(svc))
+		r.Post(riddleParetoEndpoint, paretoRiddle(svc))
+		r.Get(riddleOracleEndpoint, oracleRiddle(svc))
+		r.Get(riddleBigEndpoint, bigRiddle(svc))
+		r.Get(riddleStreamEndpoint, streamRiddle(svc))
+		r.Post(riddleSolutionsEndpoint, countRiddle(svc))
+		r.Post(riddleShareEndpoint, shareRiddle(svc))
+		r.Post(riddleMixEndpoint, mixRiddle(svc))
+		r.Post(riddleScheduleEndpoint, scheduleRiddle(svc))
+		r.Get(riddleNearestEndpoint, nearestRiddle(svc))
+		r.Post(riddleDesignEndpoint, designJugs(svc))
+		r.Post(riddleInferEndpoint, inferCapacities(svc))
+		r.Post(riddleRecipeEndpoint, recipeRiddle(svc))
 	})
 
 	return r
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"water-jug-riddle-service/service"
@@ -12,6 +13,21 @@ type APIError struct {
 	Message     string `json:"message,omitempty"`
 }
 
+// decodeHTTPBody decodes the JSON body of the request into v, rejecting unknown fields
+func decodeHTTPBody(r *http.Request, v interface{}) *service.AppError {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(v); err != nil {
+		return &service.AppError{
+			Error:   fmt.Errorf("body is not valid: %v", err),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return nil
+}
+
 func encodeHTTPResponse(w http.ResponseWriter, response interface{}) *service.AppError {
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
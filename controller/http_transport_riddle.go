@@ -2,6 +2,7 @@ package controller
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"water-jug-riddle-service/service"
@@ -11,12 +12,34 @@ const (
 	xQueryParam = "x"
 	yQueryParam = "y"
 	zQueryParam = "z"
+
+	optimalQueryParam = "optimal"
+	totalQueryParam   = "total"
+	limitQueryParam   = "limit"
+	cursorQueryParam  = "cursor"
+	formatQueryParam  = "format"
+
+	// compactFormat groups repeated operations into blocks
+	compactFormat = "compact"
+
+	// defaultPageLimit is the amount of operations of a page when only the cursor is provided
+	defaultPageLimit = 50
+	maxPageLimit     = 1000
 )
 
 type RiddleRequest struct {
 	X int `json:"x,omitempty"`
 	Y int `json:"y,omitempty"`
 	Z int `json:"z,omitempty"`
+
+	Optimal bool `json:"optimal,omitempty"`
+	Total   bool `json:"total,omitempty"`
+
+	// Limit is only present when a page of operations is requested
+	Limit  int    `json:"limit,omitempty"`
+	Cursor string `json:"cursor,omitempty"`
+
+	Compact bool `json:"compact,omitempty"`
 }
 
 func riddle(svc service.Service) func(w http.ResponseWriter, r *http.Request) {
@@ -27,12 +50,39 @@ func riddle(svc service.Service) func(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		response, err := svc.Riddle(req.X, req.Y, req.Z)
+		var response *service.RiddleResponse
+		switch {
+		case req.Total:
+			response, err = svc.SolveRiddle(&service.RiddleSpec{
+				Capacities: []int{req.X, req.Y},
+				Names:      []string{xQueryParam, yQueryParam},
+				Z:          req.Z,
+				Total:      true,
+			})
+		case req.Optimal:
+			response, err = svc.OptimalRiddle(req.X, req.Y, req.Z)
+		case req.Limit > 0:
+			response, err = svc.RiddlePage(req.X, req.Y, req.Z, req.Cursor, req.Limit)
+		case req.Compact:
+			// The solution is compressed while it's generated, so its operations are never held in memory
+			compressor := service.NewBlockCompressor()
+			response, err = svc.StreamRiddle(req.X, req.Y, req.Z, compressor.Add)
+			if err == nil {
+				response.Blocks = compressor.Blocks()
+			}
+		default:
+			response, err = svc.Riddle(req.X, req.Y, req.Z)
+		}
 		if err != nil {
 			encodeHTTPError(err, w)
 			return
 		}
 
+		if req.Compact && response.Operations != nil {
+			response.Blocks = service.CompressOperations(response.Operations)
+			response.Operations = nil
+		}
+
 		if err := encodeHTTPResponse(w, response); err != nil {
 			encodeHTTPError(err, w)
 		}
@@ -40,9 +90,91 @@ func riddle(svc service.Service) func(w http.ResponseWriter, r *http.Request) {
 }
 
 func decodeRiddleRequest(r *http.Request) (*RiddleRequest, *service.AppError) {
-	x, err := getIntegerQueryParam(r, xQueryParam)
+	x, y, z, appErr := decodeJugsQueryParams(r)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	optimal, err := getBoolQueryParam(r, optimalQueryParam)
 	if err != nil {
 		return nil, &service.AppError{
+			Error:   err,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	total, err := getBoolQueryParam(r, totalQueryParam)
+	if err != nil {
+		return nil, &service.AppError{
+			Error:   err,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	limit, err := getOptionalIntegerQueryParam(r, limitQueryParam)
+	if err != nil {
+		return nil, &service.AppError{
+			Error:   err,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	format := r.URL.Query().Get(formatQueryParam)
+	if format != "" && format != compactFormat {
+		return nil, &service.AppError{
+			Error:   fmt.Errorf("%s must be %s", formatQueryParam, compactFormat),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	req := &RiddleRequest{
+		X:       x,
+		Y:       y,
+		Z:       z,
+		Optimal: optimal,
+		Total:   total,
+		Cursor:  r.URL.Query().Get(cursorQueryParam),
+		Compact: format == compactFormat,
+	}
+
+	if limit == nil && req.Cursor == "" {
+		return req, nil
+	}
+
+	// Pages are generated straight from the oracle, which only describes the default solution
+	if optimal || total || req.Compact {
+		return nil, &service.AppError{
+			Error: fmt.Errorf("%s and %s can't be used together with %s, %s or %s",
+				limitQueryParam, cursorQueryParam, optimalQueryParam, totalQueryParam, formatQueryParam),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	req.Limit = defaultPageLimit
+	if limit != nil {
+		req.Limit = *limit
+	}
+	if req.Limit < 1 || req.Limit > maxPageLimit {
+		return nil, &service.AppError{
+			Error:   fmt.Errorf("%s must be between 1 and %d", limitQueryParam, maxPageLimit),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return req, nil
+}
+
+// decodeJugsQueryParams decodes the capacities x and y of both jugs and the amount z to measure
+func decodeJugsQueryParams(r *http.Request) (int, int, int, *service.AppError) {
+	x, err := getIntegerQueryParam(r, xQueryParam)
+	if err != nil {
+		return 0, 0, 0, &service.AppError{
 			Error: err,
 			Message: "invalid parameters",
 			Code: http.StatusBadRequest,
@@ -51,7 +183,7 @@ func decodeRiddleRequest(r *http.Request) (*RiddleRequest, *service.AppError) {
 
 	y, err := getIntegerQueryParam(r, yQueryParam)
 	if err != nil {
-		return nil, &service.AppError{
+		return 0, 0, 0, &service.AppError{
 			Error: err,
 			Message: "invalid parameters",
 			Code: http.StatusBadRequest,
@@ -60,7 +192,7 @@ func decodeRiddleRequest(r *http.Request) (*RiddleRequest, *service.AppError) {
 
 	z, err := getIntegerQueryParam(r, zQueryParam)
 	if err != nil {
-		return nil, &service.AppError{
+		return 0, 0, 0, &service.AppError{
 			Error: err,
 			Message: "invalid parameters",
 			Code: http.StatusBadRequest,
@@ -68,18 +200,14 @@ func decodeRiddleRequest(r *http.Request) (*RiddleRequest, *service.AppError) {
 	}
 
 	if valid := validateRiddleRequest(x, y, z); !valid {
-		return nil, &service.AppError{
+		return 0, 0, 0, &service.AppError{
 			Error: errors.New("every param must be a positive integer"),
 			Message: "invalid parameters",
 			Code: http.StatusBadRequest,
 		}
 	}
 
-	return &RiddleRequest{
-		X: x,
-		Y: y,
-		Z: z,
-	}, nil
+	return x, y, z, nil
 }
 
 func getIntegerQueryParam(r *http.Request, param string) (int, error) {
@@ -94,6 +222,19 @@ func getIntegerQueryParam(r *http.Request, param string) (int, error) {
 	return intValue, nil
 }
 
+// getBoolQueryParam returns false when the param is missing, as every boolean param is an opt-in flag
+func getBoolQueryParam(r *http.Request, param string) (bool, error) {
+	stringValue := r.URL.Query().Get(param)
+	if stringValue == "" {
+		return false, nil
+	}
+	boolValue, err := strconv.ParseBool(stringValue)
+	if err != nil {
+		return false, errors.New("value is not boolean")
+	}
+	return boolValue, nil
+}
+
 func validateRiddleRequest(x, y, z int) bool {
 	return x > 0 && y > 0 && z > 0
 }
@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"water-jug-riddle-service/service"
+)
+
+type BigRiddleRequest struct {
+	X *big.Int `json:"x,omitempty"`
+	Y *big.Int `json:"y,omitempty"`
+	Z *big.Int `json:"z,omitempty"`
+}
+
+func bigRiddle(svc service.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decodeBigRiddleRequest(r)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		response, err := svc.BigRiddle(req.X, req.Y, req.Z)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		if err := encodeHTTPResponse(w, response); err != nil {
+			encodeHTTPError(err, w)
+		}
+	}
+}
+
+func decodeBigRiddleRequest(r *http.Request) (*BigRiddleRequest, *service.AppError) {
+	x, err := getBigIntegerQueryParam(r, xQueryParam)
+	if err != nil {
+		return nil, &service.AppError{
+			Error:   err,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	y, err := getBigIntegerQueryParam(r, yQueryParam)
+	if err != nil {
+		return nil, &service.AppError{
+			Error:   err,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	z, err := getBigIntegerQueryParam(r, zQueryParam)
+	if err != nil {
+		return nil, &service.AppError{
+			Error:   err,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if valid := validateBigRiddleRequest(x, y, z); !valid {
+		return nil, &service.AppError{
+			Error:   errors.New("every param must be a positive integer"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return &BigRiddleRequest{
+		X: x,
+		Y: y,
+		Z: z,
+	}, nil
+}
+
+func getBigIntegerQueryParam(r *http.Request, param string) (*big.Int, error) {
+	stringValue := r.URL.Query().Get(param)
+	if stringValue == "" {
+		return nil, errors.New("every param must be a positive integer")
+	}
+	bigValue, ok := new(big.Int).SetString(stringValue, 10)
+	if !ok {
+		return nil, errors.New("value is not integer")
+	}
+	return bigValue, nil
+}
+
+func validateBigRiddleRequest(x, y, z *big.Int) bool {
+	return x.Sign() > 0 && y.Sign() > 0 && z.Sign() > 0
+}
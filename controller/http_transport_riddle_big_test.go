@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"water-jug-riddle-service/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBigRiddleHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		svc      *ServiceMock
+		query    string
+		status   int
+		response string
+	}{
+		{
+			name:     "missing y param",
+			svc:      &ServiceMock{},
+			query:    "x=1&z=1",
+			status:   http.StatusBadRequest,
+			response: `{"description":"every param must be a positive integer","message":"invalid parameters"}`,
+		},
+		{
+			name:     "invalid z param",
+			svc:      &ServiceMock{},
+			query:    "x=1&y=1&z=1.5",
+			status:   http.StatusBadRequest,
+			response: `{"description":"value is not integer","message":"invalid parameters"}`,
+		},
+		{
+			name:     "negative x param",
+			svc:      &ServiceMock{},
+			query:    "x=-100000000000000000000&y=1&z=1",
+			status:   http.StatusBadRequest,
+			response: `{"description":"every param must be a positive integer","message":"invalid parameters"}`,
+		},
+		{
+			name: "ok",
+			svc: &ServiceMock{
+				BigRiddleFunc: func(x *big.Int, y *big.Int, z *big.Int) (*service.BigRiddleResponse, *service.AppError) {
+					return &service.BigRiddleResponse{
+						Jug:        "x",
+						TotalSteps: new(big.Int).Add(x, y),
+						Gcd: &service.BezoutIdentity{
+							X:     big.NewInt(1),
+							Y:     big.NewInt(-1),
+							Value: z,
+						},
+					}, nil
+				},
+			},
+			query:    "x=100000000000000000001&y=100000000000000000000&z=1",
+			status:   http.StatusOK,
+			response: `{"jug":"x","total_steps":200000000000000000001,"gcd":{"x":1,"y":-1,"value":1}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.svc)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s?%s", riddleBigEndpoint, tt.query), nil)
+			h.ServeHTTP(w, r)
+
+			rawbody, _ := ioutil.ReadAll(w.Body)
+
+			a := assert.New(t)
+			a.Equal(tt.status, w.Code)
+			a.True(json.Valid(rawbody))
+			a.JSONEq(tt.response, string(rawbody))
+		})
+	}
+}
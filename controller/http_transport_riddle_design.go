@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"water-jug-riddle-service/service"
+)
+
+const (
+	// defaultDesignLimit is the amount of designs returned when the limit is missing
+	defaultDesignLimit = 5
+	maxDesignLimit     = 100
+)
+
+func designJugs(svc service.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		spec, err := decodeDesignSpec(r)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		limit, err := decodeDesignLimit(r)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		response, err := svc.DesignJugs(spec, limit)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		if err := encodeHTTPResponse(w, response); err != nil {
+			encodeHTTPError(err, w)
+		}
+	}
+}
+
+func decodeDesignSpec(r *http.Request) (*service.DesignSpec, *service.AppError) {
+	var spec service.DesignSpec
+	if err := decodeHTTPBody(r, &spec); err != nil {
+		return nil, err
+	}
+
+	if valid := validateDesignSpec(&spec); !valid {
+		return nil, &service.AppError{
+			Error:   errors.New("every amount and max capacity must be a positive integer"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return &spec, nil
+}
+
+func validateDesignSpec(spec *service.DesignSpec) bool {
+	if spec.MaxCapacity <= 0 {
+		return false
+	}
+	for _, amount := range spec.Amounts {
+		if amount <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func decodeDesignLimit(r *http.Request) (int, *service.AppError) {
+	limit, err := getOptionalIntegerQueryParam(r, limitQueryParam)
+	if err != nil {
+		return 0, &service.AppError{
+			Error:   err,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if limit == nil {
+		return defaultDesignLimit, nil
+	}
+
+	if *limit < 1 || *limit > maxDesignLimit {
+		return 0, &service.AppError{
+			Error:   fmt.Errorf("%s must be between 1 and %d", limitQueryParam, maxDesignLimit),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return *limit, nil
+}
@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"water-jug-riddle-service/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDesignJugsHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		svc      *ServiceMock
+		query    string
+		body     string
+		status   int
+		response interface{}
+		wantErr  bool
+	}{
+		{
+			name: "missing max capacity",
+			svc:  &ServiceMock{},
+			body: `{"amounts": [1, 2, 4]}`,
+			response: &APIError{
+				Description: "every amount and max capacity must be a positive integer",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name:  "limit out of range",
+			svc:   &ServiceMock{},
+			query: "limit=0",
+			body:  `{"amounts": [1, 2, 4], "max_capacity": 6}`,
+			response: &APIError{
+				Description: fmt.Sprintf("limit must be between 1 and %d", maxDesignLimit),
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "ok with default limit",
+			svc: &ServiceMock{
+				DesignJugsFunc: func(spec *service.DesignSpec, limit int) (*service.DesignResponse, *service.AppError) {
+					return &service.DesignResponse{
+						Designs: []service.JugDesign{
+							{X: 1, Y: spec.MaxCapacity - 2, WorstSteps: limit - 1, TotalSteps: 6},
+						},
+					}, nil
+				},
+			},
+			body:   `{"amounts": [1, 2, 4], "max_capacity": 6}`,
+			status: http.StatusOK,
+			response: &service.DesignResponse{
+				Designs: []service.JugDesign{
+					{X: 1, Y: 4, WorstSteps: 4, TotalSteps: 6},
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.svc)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s?%s", riddleDesignEndpoint, tt.query),
+				strings.NewReader(tt.body))
+			h.ServeHTTP(w, r)
+
+			rawbody, _ := ioutil.ReadAll(w.Body)
+
+			a := assert.New(t)
+			a.Equal(tt.status, w.Code)
+
+			if tt.wantErr {
+				var body APIError
+				if err := json.Unmarshal(rawbody, &body); err != nil {
+					t.Fatalf("error unmarshalling result")
+				}
+
+				a.Equal(tt.response, &body)
+			} else {
+				var body service.DesignResponse
+				if err := json.Unmarshal(rawbody, &body); err != nil {
+					t.Fatalf("error unmarshalling result")
+				}
+
+				a.Equal(tt.response, &body)
+			}
+		})
+	}
+}
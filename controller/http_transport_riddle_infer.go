@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"water-jug-riddle-service/service"
+)
+
+func inferCapacities(svc service.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		spec, err := decodeInferSpec(r)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		response, err := svc.InferCapacities(spec)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		if err := encodeHTTPResponse(w, response); err != nil {
+			encodeHTTPError(err, w)
+		}
+	}
+}
+
+func decodeInferSpec(r *http.Request) (*service.InferSpec, *service.AppError) {
+	var spec service.InferSpec
+	if err := decodeHTTPBody(r, &spec); err != nil {
+		return nil, err
+	}
+
+	if len(spec.Operations) == 0 {
+		return nil, &service.AppError{
+			Error:   errors.New("at least one operation is required"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if valid := validateInferSpec(&spec); !valid {
+		return nil, &service.AppError{
+			Error:   errors.New("every amount must be a positive integer and jugs can't be negative"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return &spec, nil
+}
+
+func validateInferSpec(spec *service.InferSpec) bool {
+	if spec.Jugs < 0 {
+		return false
+	}
+	for _, operation := range spec.Operations {
+		if operation.WaterAmount <= 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"water-jug-riddle-service/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferCapacitiesHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		svc      *ServiceMock
+		body     string
+		status   int
+		response interface{}
+		wantErr  bool
+	}{
+		{
+			name: "unknown field",
+			svc:  &ServiceMock{},
+			body: `{"operations": [], "capacities": [3, 5]}`,
+			response: &APIError{
+				Description: `body is not valid: json: unknown field "capacities"`,
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "operations are missing",
+			svc:  &ServiceMock{},
+			body: `{"operations": []}`,
+			response: &APIError{
+				Description: "at least one operation is required",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "negative amount",
+			svc:  &ServiceMock{},
+			body: `{"operations": [{"operation": "fill", "amount": 3}, {"operation": "pour", "amount": -3}]}`,
+			response: &APIError{
+				Description: "every amount must be a positive integer and jugs can't be negative",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "negative jugs",
+			svc:  &ServiceMock{},
+			body: `{"operations": [{"operation": "fill", "amount": 3}], "jugs": -1}`,
+			response: &APIError{
+				Description: "every amount must be a positive integer and jugs can't be negative",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "ok",
+			svc: &ServiceMock{
+				InferCapacitiesFunc: func(spec *service.InferSpec) (*service.InferResponse, *service.AppError) {
+					return &service.InferResponse{
+						Candidates: [][]service.InferredJug{
+							{{Capacity: spec.Operations[0].WaterAmount}, {MinCapacity: 3, Level: 3}},
+						},
+					}, nil
+				},
+			},
+			body:   `{"operations": [{"operation": "fill", "amount": 3}, {"operation": "pour", "amount": 3}]}`,
+			status: http.StatusOK,
+			response: &service.InferResponse{
+				Candidates: [][]service.InferredJug{
+					{{Capacity: 3}, {MinCapacity: 3, Level: 3}},
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.svc)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, riddleInferEndpoint, strings.NewReader(tt.body))
+			h.ServeHTTP(w, r)
+
+			rawbody, _ := ioutil.ReadAll(w.Body)
+
+			a := assert.New(t)
+			a.Equal(tt.status, w.Code)
+
+			if tt.wantErr {
+				var body APIError
+				if err := json.Unmarshal(rawbody, &body); err != nil {
+					t.Fatalf("error unmarshalling result")
+				}
+
+				a.Equal(tt.response, &body)
+			} else {
+				var body service.InferResponse
+				if err := json.Unmarshal(rawbody, &body); err != nil {
+					t.Fatalf("error unmarshalling result")
+				}
+
+				a.Equal(tt.response, &body)
+			}
+		})
+	}
+}
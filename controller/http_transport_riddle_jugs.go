@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"water-jug-riddle-service/service"
+)
+
+func solveRiddle(svc service.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		spec, err := decodeRiddleSpec(r)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		response, err := svc.SolveRiddle(spec)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		if err := encodeHTTPResponse(w, response); err != nil {
+			encodeHTTPError(err, w)
+		}
+	}
+}
+
+func decodeRiddleSpec(r *http.Request) (*service.RiddleSpec, *service.AppError) {
+	var spec service.RiddleSpec
+	if err := decodeHTTPBody(r, &spec); err != nil {
+		return nil, err
+	}
+
+	if valid := validateRiddleSpec(&spec); !valid {
+		return nil, &service.AppError{
+			Error:   errors.New("every capacity and z must be a positive integer"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return &spec, nil
+}
+
+// validateRiddleSpec allows z to be missing only when the levels of the goal are enough to solve the riddle
+func validateRiddleSpec(spec *service.RiddleSpec) bool {
+	if len(spec.Capacities) == 0 || spec.Z < 0 {
+		return false
+	}
+	if spec.Z == 0 && (spec.Goal == nil || len(spec.Goal.Levels) == 0) {
+		return false
+	}
+	for _, capacity := range spec.Capacities {
+		if capacity <= 0 {
+			return false
+		}
+	}
+	return true
+}
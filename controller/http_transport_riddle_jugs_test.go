@@ -0,0 +1,204 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"water-jug-riddle-service/service"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolveRiddleHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		svc      *ServiceMock
+		body     string
+		status   int
+		response interface{}
+		wantErr  bool
+	}{
+		{
+			name: "invalid body",
+			svc:  &ServiceMock{},
+			body: `{"capacities": "3"}`,
+			response: &APIError{
+				Description: "body is not valid: json: cannot unmarshal string into Go struct field RiddleSpec.capacities of type []int",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "unknown field",
+			svc:  &ServiceMock{},
+			body: `{"capacities": [3, 5], "w": 4}`,
+			response: &APIError{
+				Description: `body is not valid: json: unknown field "w"`,
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "missing capacities",
+			svc:  &ServiceMock{},
+			body: `{"z": 4}`,
+			response: &APIError{
+				Description: "every capacity and z must be a positive integer",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "negative capacity",
+			svc:  &ServiceMock{},
+			body: `{"capacities": [3, -5], "z": 4}`,
+			response: &APIError{
+				Description: "every capacity and z must be a positive integer",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "error with service",
+			svc: &ServiceMock{
+				SolveRiddleFunc: func(spec *service.RiddleSpec) (*service.RiddleResponse, *service.AppError) {
+					return nil, &service.AppError{
+						Error:   errors.New("some error"),
+						Message: "some message",
+						Code:    http.StatusInternalServerError,
+					}
+				},
+			},
+			body: `{"capacities": [3, 5, 7], "z": 1}`,
+			response: &APIError{
+				Description: "some error",
+				Message:     "some message",
+			},
+			status:  http.StatusInternalServerError,
+			wantErr: true,
+		},
+		{
+			name: "ok",
+			svc: &ServiceMock{
+				SolveRiddleFunc: func(spec *service.RiddleSpec) (*service.RiddleResponse, *service.AppError) {
+					return &service.RiddleResponse{
+						Operations: []service.Operation{
+							{
+								OperationType: "type",
+								Jug:           aws.String(spec.Names[0]),
+								WaterAmount:   spec.Capacities[0],
+								Step:          1,
+								Description:   "description",
+							},
+						},
+						Jug:        spec.Names[0],
+						TotalSteps: 1,
+						Optimal:    true,
+					}, nil
+				},
+			},
+			body:   `{"capacities": [3, 5, 7], "names": ["a", "b", "c"], "z": 3}`,
+			status: http.StatusOK,
+			response: &service.RiddleResponse{
+				Operations: []service.Operation{
+					{
+						OperationType: "type",
+						Jug:           aws.String("a"),
+						WaterAmount:   3,
+						Step:          1,
+						Description:   "description",
+					},
+				},
+				Jug:        "a",
+				TotalSteps: 1,
+				Optimal:    true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "ok with initial levels",
+			svc: &ServiceMock{
+				SolveRiddleFunc: func(spec *service.RiddleSpec) (*service.RiddleResponse, *service.AppError) {
+					return &service.RiddleResponse{
+						Jug:    "1",
+						Levels: map[string]int{"1": spec.Initial[0], "2": spec.Initial[1]},
+					}, nil
+				},
+			},
+			body:   `{"capacities": [3, 5], "initial": [3, 1], "z": 3}`,
+			status: http.StatusOK,
+			response: &service.RiddleResponse{
+				Jug:    "1",
+				Levels: map[string]int{"1": 3, "2": 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing z with a goal jug",
+			svc:  &ServiceMock{},
+			body: `{"capacities": [3, 5], "goal": {"jug": "1"}}`,
+			response: &APIError{
+				Description: "every capacity and z must be a positive integer",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "ok with goal levels and no z",
+			svc: &ServiceMock{
+				SolveRiddleFunc: func(spec *service.RiddleSpec) (*service.RiddleResponse, *service.AppError) {
+					return &service.RiddleResponse{
+						Jug:    "1=3, 2=5",
+						Levels: spec.Goal.Levels,
+					}, nil
+				},
+			},
+			body:   `{"capacities": [3, 5], "goal": {"levels": {"1": 3, "2": 5}}}`,
+			status: http.StatusOK,
+			response: &service.RiddleResponse{
+				Jug:    "1=3, 2=5",
+				Levels: map[string]int{"1": 3, "2": 5},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.svc)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, riddleEndpoint, strings.NewReader(tt.body))
+			h.ServeHTTP(w, r)
+
+			rawbody, _ := ioutil.ReadAll(w.Body)
+
+			a := assert.New(t)
+			a.Equal(tt.status, w.Code)
+
+			if tt.wantErr {
+				var body APIError
+				if err := json.Unmarshal(rawbody, &body); err != nil {
+					t.Fatalf("error unmarshalling result")
+				}
+
+				a.Equal(tt.response, &body)
+			} else {
+				var body service.RiddleResponse
+				if err := json.Unmarshal(rawbody, &body); err != nil {
+					t.Fatalf("error unmarshalling result")
+				}
+
+				a.Equal(tt.response, &body)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"water-jug-riddle-service/service"
+)
+
+func mixRiddle(svc service.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		spec, err := decodeMixSpec(r)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		response, err := svc.MixRiddle(spec)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		if err := encodeHTTPResponse(w, response); err != nil {
+			encodeHTTPError(err, w)
+		}
+	}
+}
+
+func decodeMixSpec(r *http.Request) (*service.MixSpec, *service.AppError) {
+	var spec service.MixSpec
+	if err := decodeHTTPBody(r, &spec); err != nil {
+		return nil, err
+	}
+
+	if valid := validateMixSpec(&spec); !valid {
+		return nil, &service.AppError{
+			Error:   errors.New("every capacity and volume must be a positive integer"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return &spec, nil
+}
+
+func validateMixSpec(spec *service.MixSpec) bool {
+	if spec.Volume <= 0 {
+		return false
+	}
+	for _, capacity := range spec.Capacities {
+		if capacity <= 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"water-jug-riddle-service/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMixRiddleHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		svc      *ServiceMock
+		body     string
+		status   int
+		response interface{}
+		wantErr  bool
+	}{
+		{
+			name: "missing volume",
+			svc:  &ServiceMock{},
+			body: `{"capacities": [2, 3], "concentration": "1/2"}`,
+			response: &APIError{
+				Description: "every capacity and volume must be a positive integer",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "ok",
+			svc: &ServiceMock{
+				MixRiddleFunc: func(spec *service.MixSpec) (*service.RiddleResponse, *service.AppError) {
+					return &service.RiddleResponse{
+						Jug:        "1",
+						TotalSteps: 5,
+						Levels:     map[string]int{"1": spec.Volume, "2": 3},
+						Mixtures: map[string]map[string]string{
+							"1": {"water": "1", "syrup": "1"},
+							"2": {"water": "3"},
+						},
+						Optimal: true,
+					}, nil
+				},
+			},
+			body:   `{"capacities": [2, 3], "volume": 2, "concentration": "1/2"}`,
+			status: http.StatusOK,
+			response: &service.RiddleResponse{
+				Jug:        "1",
+				TotalSteps: 5,
+				Levels:     map[string]int{"1": 2, "2": 3},
+				Mixtures: map[string]map[string]string{
+					"1": {"water": "1", "syrup": "1"},
+					"2": {"water": "3"},
+				},
+				Optimal: true,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.svc)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, riddleMixEndpoint, strings.NewReader(tt.body))
+			h.ServeHTTP(w, r)
+
+			rawbody, _ := ioutil.ReadAll(w.Body)
+
+			a := assert.New(t)
+			a.Equal(tt.status, w.Code)
+
+			if tt.wantErr {
+				var body APIError
+				if err := json.Unmarshal(rawbody, &body); err != nil {
+					t.Fatalf("error unmarshalling result")
+				}
+
+				a.Equal(tt.response, &body)
+			} else {
+				var body service.RiddleResponse
+				if err := json.Unmarshal(rawbody, &body); err != nil {
+					t.Fatalf("error unmarshalling result")
+				}
+
+				a.Equal(tt.response, &body)
+			}
+		})
+	}
+}
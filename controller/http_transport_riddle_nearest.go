@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"water-jug-riddle-service/service"
+)
+
+const (
+	maxStepsQueryParam = "max_steps"
+	// maxNearestSteps is the biggest budget of steps, as the states within it are searched even for huge jugs
+	maxNearestSteps = 1000
+)
+
+type NearestRequest struct {
+	X        int  `json:"x,omitempty"`
+	Y        int  `json:"y,omitempty"`
+	Z        int  `json:"z,omitempty"`
+	MaxSteps *int `json:"max_steps,omitempty"`
+}
+
+func nearestRiddle(svc service.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decodeNearestRequest(r)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		response, err := svc.NearestRiddle(req.X, req.Y, req.Z, req.MaxSteps)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		if err := encodeHTTPResponse(w, response); err != nil {
+			encodeHTTPError(err, w)
+		}
+	}
+}
+
+func decodeNearestRequest(r *http.Request) (*NearestRequest, *service.AppError) {
+	x, y, z, appErr := decodeJugsQueryParams(r)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	maxSteps, err := getOptionalIntegerQueryParam(r, maxStepsQueryParam)
+	if err != nil {
+		return nil, &service.AppError{
+			Error:   err,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if maxSteps != nil && (*maxSteps < 1 || *maxSteps > maxNearestSteps) {
+		return nil, &service.AppError{
+			Error:   fmt.Errorf("%s must be between 1 and %d", maxStepsQueryParam, maxNearestSteps),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return &NearestRequest{
+		X:        x,
+		Y:        y,
+		Z:        z,
+		MaxSteps: maxSteps,
+	}, nil
+}
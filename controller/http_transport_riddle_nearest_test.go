@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"water-jug-riddle-service/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNearestHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		svc      *ServiceMock
+		query    string
+		status   int
+		response interface{}
+		wantErr  bool
+	}{
+		{
+			name:  "missing z param",
+			svc:   &ServiceMock{},
+			query: "x=2&y=4",
+			response: &APIError{
+				Description: "every param must be a positive integer",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name:  "invalid max_steps param",
+			svc:   &ServiceMock{},
+			query: "x=2&y=4&z=3&max_steps=a",
+			response: &APIError{
+				Description: "value is not integer",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name:  "max_steps param is not positive",
+			svc:   &ServiceMock{},
+			query: "x=2&y=4&z=3&max_steps=0",
+			response: &APIError{
+				Description: "max_steps must be between 1 and 1000",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name:  "max_steps param is too big",
+			svc:   &ServiceMock{},
+			query: "x=2&y=4&z=3&max_steps=1001",
+			response: &APIError{
+				Description: "max_steps must be between 1 and 1000",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "ok with max_steps",
+			svc: &ServiceMock{
+				NearestRiddleFunc: func(x int, y int, z int, maxSteps *int) (*service.NearestResponse, *service.AppError) {
+					return &service.NearestResponse{
+						Plans: []service.NearestPlan{
+							{Amount: x, RiddleResponse: service.RiddleResponse{Jug: "x", TotalSteps: *maxSteps - 1}},
+							{Amount: y, RiddleResponse: service.RiddleResponse{Jug: "y", TotalSteps: *maxSteps - 1}},
+						},
+					}, nil
+				},
+			},
+			query:  "x=2&y=4&z=3&max_steps=2",
+			status: http.StatusOK,
+			response: &service.NearestResponse{
+				Plans: []service.NearestPlan{
+					{Amount: 2, RiddleResponse: service.RiddleResponse{Jug: "x", TotalSteps: 1}},
+					{Amount: 4, RiddleResponse: service.RiddleResponse{Jug: "y", TotalSteps: 1}},
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.svc)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s?%s", riddleNearestEndpoint, tt.query), nil)
+			h.ServeHTTP(w, r)
+
+			rawbody, _ := ioutil.ReadAll(w.Body)
+
+			a := assert.New(t)
+			a.Equal(tt.status, w.Code)
+
+			if tt.wantErr {
+				var body APIError
+				if err := json.Unmarshal(rawbody, &body); err != nil {
+					t.Fatalf("error unmarshalling result")
+				}
+
+				a.Equal(tt.response, &body)
+			} else {
+				var body service.NearestResponse
+				if err := json.Unmarshal(rawbody, &body); err != nil {
+					t.Fatalf("error unmarshalling result")
+				}
+
+				a.Equal(tt.response, &body)
+			}
+		})
+	}
+}
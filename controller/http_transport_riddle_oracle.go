@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"water-jug-riddle-service/service"
+)
+
+const (
+	stepQueryParam = "step"
+)
+
+type OracleRequest struct {
+	X    int  `json:"x,omitempty"`
+	Y    int  `json:"y,omitempty"`
+	Z    int  `json:"z,omitempty"`
+	Step *int `json:"step,omitempty"`
+}
+
+func oracleRiddle(svc service.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decodeOracleRequest(r)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		response, err := svc.OracleRiddle(req.X, req.Y, req.Z, req.Step)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		if err := encodeHTTPResponse(w, response); err != nil {
+			encodeHTTPError(err, w)
+		}
+	}
+}
+
+func decodeOracleRequest(r *http.Request) (*OracleRequest, *service.AppError) {
+	x, y, z, appErr := decodeJugsQueryParams(r)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	step, err := getOptionalIntegerQueryParam(r, stepQueryParam)
+	if err != nil {
+		return nil, &service.AppError{
+			Error:   err,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if step != nil && *step < 0 {
+		return nil, &service.AppError{
+			Error:   errors.New("step can't be negative"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return &OracleRequest{
+		X:    x,
+		Y:    y,
+		Z:    z,
+		Step: step,
+	}, nil
+}
+
+// getOptionalIntegerQueryParam returns nil when the param is missing
+func getOptionalIntegerQueryParam(r *http.Request, param string) (*int, error) {
+	stringValue := r.URL.Query().Get(param)
+	if stringValue == "" {
+		return nil, nil
+	}
+	intValue, err := strconv.Atoi(stringValue)
+	if err != nil {
+		return nil, errors.New("value is not integer")
+	}
+	return &intValue, nil
+}
@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"water-jug-riddle-service/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOracleHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		svc      *ServiceMock
+		query    string
+		status   int
+		response interface{}
+		wantErr  bool
+	}{
+		{
+			name:  "missing z param",
+			svc:   &ServiceMock{},
+			query: "x=1&y=2",
+			response: &APIError{
+				Description: "every param must be a positive integer",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name:  "invalid step param",
+			svc:   &ServiceMock{},
+			query: "x=1&y=2&z=1&step=a",
+			response: &APIError{
+				Description: "value is not integer",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name:  "negative step param",
+			svc:   &ServiceMock{},
+			query: "x=1&y=2&z=1&step=-1",
+			response: &APIError{
+				Description: "step can't be negative",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "ok without step",
+			svc: &ServiceMock{
+				OracleRiddleFunc: func(x int, y int, z int, step *int) (*service.OracleResponse, *service.AppError) {
+					response := &service.OracleResponse{
+						Jug:        "x",
+						TotalSteps: 3,
+					}
+					if step != nil {
+						response.State = &service.JugsState{Step: *step}
+					}
+					return response, nil
+				},
+			},
+			query:  "x=1&y=2&z=1",
+			status: http.StatusOK,
+			response: &service.OracleResponse{
+				Jug:        "x",
+				TotalSteps: 3,
+			},
+			wantErr: false,
+		},
+		{
+			name: "ok with step",
+			svc: &ServiceMock{
+				OracleRiddleFunc: func(x int, y int, z int, step *int) (*service.OracleResponse, *service.AppError) {
+					return &service.OracleResponse{
+						Jug:        "x",
+						TotalSteps: 3,
+						State: &service.JugsState{
+							Step:   *step,
+							Levels: map[string]int{"x": 1, "y": 0},
+						},
+					}, nil
+				},
+			},
+			query:  "x=1&y=2&z=1&step=2",
+			status: http.StatusOK,
+			response: &service.OracleResponse{
+				Jug:        "x",
+				TotalSteps: 3,
+				State: &service.JugsState{
+					Step:   2,
+					Levels: map[string]int{"x": 1, "y": 0},
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.svc)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s?%s", riddleOracleEndpoint, tt.query), nil)
+			h.ServeHTTP(w, r)
+
+			rawbody, _ := ioutil.ReadAll(w.Body)
+
+			a := assert.New(t)
+			a.Equal(tt.status, w.Code)
+
+			if tt.wantErr {
+				var body APIError
+				if err := json.Unmarshal(rawbody, &body); err != nil {
+					t.Fatalf("error unmarshalling result")
+				}
+
+				a.Equal(tt.response, &body)
+			} else {
+				var body service.OracleResponse
+				if err := json.Unmarshal(rawbody, &body); err != nil {
+					t.Fatalf("error unmarshalling result")
+				}
+
+				a.Equal(tt.response, &body)
+			}
+		})
+	}
+}
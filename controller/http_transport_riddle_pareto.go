@@ -0,0 +1,26 @@
+package controller
+
+import (
+	"net/http"
+	"water-jug-riddle-service/service"
+)
+
+func paretoRiddle(svc service.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		spec, err := decodeRiddleSpec(r)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		response, err := svc.ParetoRiddle(spec)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		if err := encodeHTTPResponse(w, response); err != nil {
+			encodeHTTPError(err, w)
+		}
+	}
+}
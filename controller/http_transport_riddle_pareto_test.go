@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"water-jug-riddle-service/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParetoRiddleHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		svc      *ServiceMock
+		body     string
+		status   int
+		response interface{}
+		wantErr  bool
+	}{
+		{
+			name: "missing capacities",
+			svc:  &ServiceMock{},
+			body: `{"z": 4}`,
+			response: &APIError{
+				Description: "every capacity and z must be a positive integer",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "costs are provided",
+			svc: &ServiceMock{
+				ParetoRiddleFunc: func(spec *service.RiddleSpec) (*service.ParetoResponse, *service.AppError) {
+					return nil, &service.AppError{
+						Error:   errors.New("costs can't be used when comparing plans by steps and water"),
+						Message: "invalid parameters",
+						Code:    http.StatusBadRequest,
+					}
+				},
+			},
+			body: `{"capacities": [3, 5], "z": 4, "costs": {"fill": {"base": 1}}}`,
+			response: &APIError{
+				Description: "costs can't be used when comparing plans by steps and water",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "ok",
+			svc: &ServiceMock{
+				ParetoRiddleFunc: func(spec *service.RiddleSpec) (*service.ParetoResponse, *service.AppError) {
+					return &service.ParetoResponse{
+						Plans: []service.ParetoPlan{
+							{
+								RiddleResponse: service.RiddleResponse{Jug: "2", TotalSteps: 6},
+								WaterUsed:      10,
+								WaterWasted:    3,
+							},
+							{
+								RiddleResponse: service.RiddleResponse{Jug: "2", TotalSteps: 8},
+								WaterUsed:      9,
+								WaterWasted:    5,
+							},
+						},
+					}, nil
+				},
+			},
+			body:   `{"capacities": [3, 5], "z": 4}`,
+			status: http.StatusOK,
+			response: &service.ParetoResponse{
+				Plans: []service.ParetoPlan{
+					{
+						RiddleResponse: service.RiddleResponse{Jug: "2", TotalSteps: 6},
+						WaterUsed:      10,
+						WaterWasted:    3,
+					},
+					{
+						RiddleResponse: service.RiddleResponse{Jug: "2", TotalSteps: 8},
+						WaterUsed:      9,
+						WaterWasted:    5,
+					},
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.svc)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, riddleParetoEndpoint, strings.NewReader(tt.body))
+			h.ServeHTTP(w, r)
+
+			rawbody, _ := ioutil.ReadAll(w.Body)
+
+			a := assert.New(t)
+			a.Equal(tt.status, w.Code)
+
+			if tt.wantErr {
+				var body APIError
+				if err := json.Unmarshal(rawbody, &body); err != nil {
+					t.Fatalf("error unmarshalling result")
+				}
+
+				a.Equal(tt.response, &body)
+			} else {
+				var body service.ParetoResponse
+				if err := json.Unmarshal(rawbody, &body); err != nil {
+					t.Fatalf("error unmarshalling result")
+				}
+
+				a.Equal(tt.response, &body)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"water-jug-riddle-service/service"
+)
+
+func recipeRiddle(svc service.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		spec, err := decodeRecipeSpec(r)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		response, err := svc.RecipeRiddle(spec)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		if err := encodeHTTPResponse(w, response); err != nil {
+			encodeHTTPError(err, w)
+		}
+	}
+}
+
+func decodeRecipeSpec(r *http.Request) (*service.RecipeSpec, *service.AppError) {
+	var spec service.RecipeSpec
+	if err := decodeHTTPBody(r, &spec); err != nil {
+		return nil, err
+	}
+
+	if valid := validateRecipeSpec(&spec); !valid {
+		return nil, &service.AppError{
+			Error:   errors.New("every capacity and target must be a positive integer"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return &spec, nil
+}
+
+func validateRecipeSpec(spec *service.RecipeSpec) bool {
+	if len(spec.Capacities) == 0 {
+		return false
+	}
+	for _, capacity := range spec.Capacities {
+		if capacity <= 0 {
+			return false
+		}
+	}
+	for _, target := range spec.Targets {
+		if target <= 0 {
+			return false
+		}
+	}
+	return true
+}
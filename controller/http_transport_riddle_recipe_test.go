@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"water-jug-riddle-service/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecipeRiddleHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		svc      *ServiceMock
+		body     string
+		status   int
+		response interface{}
+		wantErr  bool
+	}{
+		{
+			name: "negative target",
+			svc:  &ServiceMock{},
+			body: `{"capacities": [3, 5], "targets": [2, -3]}`,
+			response: &APIError{
+				Description: "every capacity and target must be a positive integer",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "ok",
+			svc: &ServiceMock{
+				RecipeRiddleFunc: func(spec *service.RecipeSpec) (*service.RiddleResponse, *service.AppError) {
+					return &service.RiddleResponse{
+						TotalSteps: 2 * len(spec.Targets),
+						Levels:     map[string]int{"1": 0, "2": 0},
+						Optimal:    true,
+					}, nil
+				},
+			},
+			body:   `{"capacities": [3, 5], "targets": [2, 3]}`,
+			status: http.StatusOK,
+			response: &service.RiddleResponse{
+				TotalSteps: 4,
+				Levels:     map[string]int{"1": 0, "2": 0},
+				Optimal:    true,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.svc)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, riddleRecipeEndpoint, strings.NewReader(tt.body))
+			h.ServeHTTP(w, r)
+
+			rawbody, _ := ioutil.ReadAll(w.Body)
+
+			a := assert.New(t)
+			a.Equal(tt.status, w.Code)
+
+			if tt.wantErr {
+				var body APIError
+				if err := json.Unmarshal(rawbody, &body); err != nil {
+					t.Fatalf("error unmarshalling result")
+				}
+
+				a.Equal(tt.response, &body)
+			} else {
+				var body service.RiddleResponse
+				if err := json.Unmarshal(rawbody, &body); err != nil {
+					t.Fatalf("error unmarshalling result")
+				}
+
+				a.Equal(tt.response, &body)
+			}
+		})
+	}
+}
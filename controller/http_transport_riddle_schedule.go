@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"water-jug-riddle-service/service"
+)
+
+func scheduleRiddle(svc service.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		spec, err := decodeScheduleSpec(r)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		response, err := svc.ScheduleRiddle(spec)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		if err := encodeHTTPResponse(w, response); err != nil {
+			encodeHTTPError(err, w)
+		}
+	}
+}
+
+func decodeScheduleSpec(r *http.Request) (*service.ScheduleSpec, *service.AppError) {
+	var spec service.ScheduleSpec
+	if err := decodeHTTPBody(r, &spec); err != nil {
+		return nil, err
+	}
+
+	if valid := validateRiddleSpec(&spec.RiddleSpec); !valid {
+		return nil, &service.AppError{
+			Error:   errors.New("every capacity and z must be a positive integer"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return &spec, nil
+}
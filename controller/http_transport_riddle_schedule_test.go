@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"water-jug-riddle-service/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleRiddleHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		svc      *ServiceMock
+		body     string
+		status   int
+		response interface{}
+		wantErr  bool
+	}{
+		{
+			name: "missing z",
+			svc:  &ServiceMock{},
+			body: `{"capacities": [3, 5], "rates": {"tap": 1, "pour": 2, "drain": 2}}`,
+			response: &APIError{
+				Description: "every capacity and z must be a positive integer",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "ok",
+			svc: &ServiceMock{
+				ScheduleRiddleFunc: func(spec *service.ScheduleSpec) (*service.ScheduleResponse, *service.AppError) {
+					return &service.ScheduleResponse{
+						Jug:        "3",
+						TotalSteps: 4,
+						Levels:     map[string]int{"1": 0, "2": 0, "3": spec.Z},
+						TotalTime:  6.5,
+					}, nil
+				},
+			},
+			body:   `{"capacities": [2, 3, 7], "z": 5, "rates": {"tap": 1, "pour": 2, "drain": 2}, "workers": 2}`,
+			status: http.StatusOK,
+			response: &service.ScheduleResponse{
+				Jug:        "3",
+				TotalSteps: 4,
+				Levels:     map[string]int{"1": 0, "2": 0, "3": 5},
+				TotalTime:  6.5,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.svc)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, riddleScheduleEndpoint, strings.NewReader(tt.body))
+			h.ServeHTTP(w, r)
+
+			rawbody, _ := ioutil.ReadAll(w.Body)
+
+			a := assert.New(t)
+			a.Equal(tt.status, w.Code)
+
+			if tt.wantErr {
+				var body APIError
+				if err := json.Unmarshal(rawbody, &body); err != nil {
+					t.Fatalf("error unmarshalling result")
+				}
+
+				a.Equal(tt.response, &body)
+			} else {
+				var body service.ScheduleResponse
+				if err := json.Unmarshal(rawbody, &body); err != nil {
+					t.Fatalf("error unmarshalling result")
+				}
+
+				a.Equal(tt.response, &body)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"water-jug-riddle-service/service"
+)
+
+func shareRiddle(svc service.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		spec, err := decodeShareSpec(r)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		response, err := svc.ShareRiddle(spec)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		if err := encodeHTTPResponse(w, response); err != nil {
+			encodeHTTPError(err, w)
+		}
+	}
+}
+
+func decodeShareSpec(r *http.Request) (*service.ShareSpec, *service.AppError) {
+	var spec service.ShareSpec
+	if err := decodeHTTPBody(r, &spec); err != nil {
+		return nil, err
+	}
+
+	if valid := validateShareSpec(&spec); !valid {
+		return nil, &service.AppError{
+			Error:   errors.New("every capacity must be a positive integer"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return &spec, nil
+}
+
+func validateShareSpec(spec *service.ShareSpec) bool {
+	for _, capacity := range spec.Capacities {
+		if capacity <= 0 {
+			return false
+		}
+	}
+	return true
+}
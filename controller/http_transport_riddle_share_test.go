@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"water-jug-riddle-service/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShareRiddleHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		svc      *ServiceMock
+		body     string
+		status   int
+		response interface{}
+		wantErr  bool
+	}{
+		{
+			name: "unknown field",
+			svc:  &ServiceMock{},
+			body: `{"capacities": [8, 5, 3], "z": 4}`,
+			response: &APIError{
+				Description: `body is not valid: json: unknown field "z"`,
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "negative capacity",
+			svc:  &ServiceMock{},
+			body: `{"capacities": [8, -5, 3], "initial": [8, 0, 0], "goal": {"1": 4}}`,
+			response: &APIError{
+				Description: "every capacity must be a positive integer",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "ok",
+			svc: &ServiceMock{
+				ShareRiddleFunc: func(spec *service.ShareSpec) (*service.RiddleResponse, *service.AppError) {
+					return &service.RiddleResponse{
+						Jug:        "1=4, 2=4, 3=0",
+						TotalSteps: 7,
+						Levels:     map[string]int{"1": spec.Goal["1"], "2": spec.Goal["2"], "3": 0},
+						Optimal:    true,
+					}, nil
+				},
+			},
+			body:   `{"capacities": [8, 5, 3], "initial": [8, 0, 0], "goal": {"1": 4, "2": 4}}`,
+			status: http.StatusOK,
+			response: &service.RiddleResponse{
+				Jug:        "1=4, 2=4, 3=0",
+				TotalSteps: 7,
+				Levels:     map[string]int{"1": 4, "2": 4, "3": 0},
+				Optimal:    true,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.svc)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, riddleShareEndpoint, strings.NewReader(tt.body))
+			h.ServeHTTP(w, r)
+
+			rawbody, _ := ioutil.ReadAll(w.Body)
+
+			a := assert.New(t)
+			a.Equal(tt.status, w.Code)
+
+			if tt.wantErr {
+				var body APIError
+				if err := json.Unmarshal(rawbody, &body); err != nil {
+					t.Fatalf("error unmarshalling result")
+				}
+
+				a.Equal(tt.response, &body)
+			} else {
+				var body service.RiddleResponse
+				if err := json.Unmarshal(rawbody, &body); err != nil {
+					t.Fatalf("error unmarshalling result")
+				}
+
+				a.Equal(tt.response, &body)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"water-jug-riddle-service/service"
+)
+
+func countRiddle(svc service.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		spec, err := decodeRiddleSpec(r)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		limit, err := decodePlansLimit(r)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		response, err := svc.CountRiddle(spec, limit)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		if err := encodeHTTPResponse(w, response); err != nil {
+			encodeHTTPError(err, w)
+		}
+	}
+}
+
+// decodePlansLimit returns 0 when the limit is missing, so plans are only counted
+func decodePlansLimit(r *http.Request) (int, *service.AppError) {
+	limit, err := getOptionalIntegerQueryParam(r, limitQueryParam)
+	if err != nil {
+		return 0, &service.AppError{
+			Error:   err,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if limit == nil {
+		return 0, nil
+	}
+
+	if *limit < 0 || *limit > maxPageLimit {
+		return 0, &service.AppError{
+			Error:   fmt.Errorf("%s must be between 0 and %d", limitQueryParam, maxPageLimit),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return *limit, nil
+}
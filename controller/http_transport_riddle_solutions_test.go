@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"water-jug-riddle-service/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountRiddleHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		svc      *ServiceMock
+		body     string
+		limit    string
+		status   int
+		response interface{}
+		wantErr  bool
+	}{
+		{
+			name: "missing capacities",
+			svc:  &ServiceMock{},
+			body: `{"z": 4}`,
+			response: &APIError{
+				Description: "every capacity and z must be a positive integer",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name:  "invalid limit param",
+			svc:   &ServiceMock{},
+			body:  `{"capacities": [3, 5], "z": 4}`,
+			limit: "a",
+			response: &APIError{
+				Description: "value is not integer",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name:  "negative limit param",
+			svc:   &ServiceMock{},
+			body:  `{"capacities": [3, 5], "z": 4}`,
+			limit: "-1",
+			response: &APIError{
+				Description: "limit must be between 0 and 1000",
+				Message:     "invalid parameters",
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "ok",
+			svc: &ServiceMock{
+				CountRiddleFunc: func(spec *service.RiddleSpec, limit int) (*service.SolutionsResponse, *service.AppError) {
+					return &service.SolutionsResponse{
+						Count:      big.NewInt(int64(limit)),
+						TotalSteps: spec.Z,
+					}, nil
+				},
+			},
+			body:   `{"capacities": [3, 5], "z": 4}`,
+			limit:  "2",
+			status: http.StatusOK,
+			response: &service.SolutionsResponse{
+				Count:      big.NewInt(2),
+				TotalSteps: 4,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.svc)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(
+				http.MethodPost,
+				fmt.Sprintf("%s?%s=%s", riddleSolutionsEndpoint, limitQueryParam, tt.limit),
+				strings.NewReader(tt.body))
+			h.ServeHTTP(w, r)
+
+			rawbody, _ := ioutil.ReadAll(w.Body)
+
+			a := assert.New(t)
+			a.Equal(tt.status, w.Code)
+
+			if tt.wantErr {
+				var body APIError
+				if err := json.Unmarshal(rawbody, &body); err != nil {
+					t.Fatalf("error unmarshalling result")
+				}
+
+				a.Equal(tt.response, &body)
+			} else {
+				var body service.SolutionsResponse
+				if err := json.Unmarshal(rawbody, &body); err != nil {
+					t.Fatalf("error unmarshalling result")
+				}
+
+				a.Equal(tt.response, &body)
+			}
+		})
+	}
+}
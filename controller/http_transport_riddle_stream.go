@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"water-jug-riddle-service/service"
+)
+
+// streamFlushInterval is how many operations are written between flushes, besides the first one
+const streamFlushInterval = 100
+
+// ndjsonWriter writes one JSON record per line, writing the headers right before the first record
+type ndjsonWriter struct {
+	w       http.ResponseWriter
+	encoder *json.Encoder
+	records int
+}
+
+func newNDJSONWriter(w http.ResponseWriter) *ndjsonWriter {
+	return &ndjsonWriter{
+		w:       w,
+		encoder: json.NewEncoder(w),
+	}
+}
+
+func (n *ndjsonWriter) started() bool {
+	return n.records > 0
+}
+
+func (n *ndjsonWriter) write(record interface{}) error {
+	if !n.started() {
+		n.w.Header().Set("Content-Type", "application/x-ndjson; charset=utf-8")
+		n.w.WriteHeader(http.StatusOK)
+	}
+
+	if err := n.encoder.Encode(record); err != nil {
+		return err
+	}
+	n.records++
+
+	if n.records == 1 || n.records%streamFlushInterval == 0 {
+		n.flush()
+	}
+	return nil
+}
+
+func (n *ndjsonWriter) flush() {
+	if flusher, ok := n.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func streamRiddle(svc service.Service) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		x, y, z, err := decodeJugsQueryParams(r)
+		if err != nil {
+			encodeHTTPError(err, w)
+			return
+		}
+
+		stream := newNDJSONWriter(w)
+		trailer, err := svc.StreamRiddle(x, y, z, func(operation service.Operation) error {
+			return stream.write(operation)
+		})
+		if err != nil {
+			// Once the stream started, the status can't be changed anymore
+			if stream.started() {
+				log.Printf("error streaming riddle: %v", err.Error)
+				return
+			}
+			encodeHTTPError(err, w)
+			return
+		}
+
+		if err := stream.write(trailer); err != nil {
+			log.Printf("error streaming riddle: %v", err)
+			return
+		}
+		stream.flush()
+	}
+}
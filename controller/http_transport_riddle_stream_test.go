@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"water-jug-riddle-service/service"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamRiddleHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		svc         *ServiceMock
+		query       string
+		status      int
+		contentType string
+		response    string
+	}{
+		{
+			name:        "missing x param",
+			svc:         &ServiceMock{},
+			query:       "y=1&z=1",
+			status:      http.StatusBadRequest,
+			contentType: "application/json; charset=utf-8",
+			response:    `{"description":"every param must be a positive integer","message":"invalid parameters"}` + "\n",
+		},
+		{
+			name: "error with service",
+			svc: &ServiceMock{
+				StreamRiddleFunc: func(x int, y int, z int, emit func(service.Operation) error) (*service.RiddleResponse, *service.AppError) {
+					return nil, &service.AppError{
+						Error:   errors.New("some error"),
+						Message: "some message",
+						Code:    http.StatusInternalServerError,
+					}
+				},
+			},
+			query:       "x=1&y=2&z=1",
+			status:      http.StatusInternalServerError,
+			contentType: "application/json; charset=utf-8",
+			response:    `{"description":"some error","message":"some message"}` + "\n",
+		},
+		{
+			name: "ok",
+			svc: &ServiceMock{
+				StreamRiddleFunc: func(x int, y int, z int, emit func(service.Operation) error) (*service.RiddleResponse, *service.AppError) {
+					for step := 1; step <= 2; step++ {
+						if err := emit(service.Operation{
+							OperationType: "type",
+							Jug:           aws.String("x"),
+							WaterAmount:   1,
+							Step:          step,
+						}); err != nil {
+							return nil, &service.AppError{Error: err}
+						}
+					}
+					return &service.RiddleResponse{
+						Jug:        "x",
+						TotalSteps: 2,
+					}, nil
+				},
+			},
+			query:       "x=1&y=2&z=1",
+			status:      http.StatusOK,
+			contentType: "application/x-ndjson; charset=utf-8",
+			response: `{"operation":"type","jug":"x","amount":1,"step":1}` + "\n" +
+				`{"operation":"type","jug":"x","amount":1,"step":2}` + "\n" +
+				`{"jug":"x","total_steps":2}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.svc)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s?%s", riddleStreamEndpoint, tt.query), nil)
+			h.ServeHTTP(w, r)
+
+			rawbody, _ := ioutil.ReadAll(w.Body)
+
+			a := assert.New(t)
+			a.Equal(tt.status, w.Code)
+			a.Equal(tt.contentType, w.Header().Get("Content-Type"))
+			a.Equal(tt.response, string(rawbody))
+		})
+	}
+}
@@ -16,9 +16,14 @@ import (
 
 func TestHandler(t *testing.T) {
 	type args struct {
-		x string
-		y string
-		z string
+		x       string
+		y       string
+		z       string
+		optimal string
+		total   string
+		limit   string
+		cursor  string
+		format  string
 	}
 	tests := []struct {
 		name string
@@ -115,6 +120,104 @@ func TestHandler(t *testing.T) {
 			status: http.StatusBadRequest,
 			wantErr: true,
 		},
+		{
+			name: "invalid optimal param",
+			svc: &ServiceMock{},
+			args: args{
+				x:       "1",
+				y:       "1",
+				z:       "1",
+				optimal: "a",
+			},
+			response: &APIError{
+				Description: "value is not boolean",
+				Message:     "invalid parameters",
+			},
+			status: http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "invalid limit param",
+			svc: &ServiceMock{},
+			args: args{
+				x:     "1",
+				y:     "1",
+				z:     "1",
+				limit: "a",
+			},
+			response: &APIError{
+				Description: "value is not integer",
+				Message:     "invalid parameters",
+			},
+			status: http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "limit param out of range",
+			svc: &ServiceMock{},
+			args: args{
+				x:     "1",
+				y:     "1",
+				z:     "1",
+				limit: "1001",
+			},
+			response: &APIError{
+				Description: "limit must be between 1 and 1000",
+				Message:     "invalid parameters",
+			},
+			status: http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "invalid format param",
+			svc: &ServiceMock{},
+			args: args{
+				x:      "1",
+				y:      "1",
+				z:      "1",
+				format: "a",
+			},
+			response: &APIError{
+				Description: "format must be compact",
+				Message:     "invalid parameters",
+			},
+			status: http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "cursor param with format",
+			svc: &ServiceMock{},
+			args: args{
+				x:      "1",
+				y:      "1",
+				z:      "1",
+				cursor: "Mg",
+				format: "compact",
+			},
+			response: &APIError{
+				Description: "limit and cursor can't be used together with optimal, total or format",
+				Message:     "invalid parameters",
+			},
+			status: http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "cursor param with optimal",
+			svc: &ServiceMock{},
+			args: args{
+				x:       "1",
+				y:       "1",
+				z:       "1",
+				optimal: "true",
+				cursor:  "Mg",
+			},
+			response: &APIError{
+				Description: "limit and cursor can't be used together with optimal, total or format",
+				Message:     "invalid parameters",
+			},
+			status: http.StatusBadRequest,
+			wantErr: true,
+		},
 		{
 			name: "error with service",
 			svc: &ServiceMock{
@@ -182,6 +285,235 @@ func TestHandler(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "ok with optimal",
+			svc: &ServiceMock{
+				OptimalRiddleFunc: func(x int, y int, z int) (*service.RiddleResponse, *service.AppError) {
+					return &service.RiddleResponse{
+						Operations: []service.Operation{
+							{
+								OperationType: "type",
+								Jug:           aws.String("x"),
+								WaterAmount:   1,
+								Step:          1,
+								Description:   "description",
+							},
+						},
+						Jug:        "x",
+						TotalSteps: 1,
+						Optimal:    true,
+					}, nil
+				},
+			},
+			args: args{
+				x:       "1",
+				y:       "1",
+				z:       "1",
+				optimal: "true",
+			},
+			status: http.StatusOK,
+			response: &service.RiddleResponse{
+				Operations: []service.Operation{
+					{
+						OperationType: "type",
+						Jug:           aws.String("x"),
+						WaterAmount:   1,
+						Step:          1,
+						Description:   "description",
+					},
+				},
+				Jug:        "x",
+				TotalSteps: 1,
+				Optimal:    true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "ok with total",
+			svc: &ServiceMock{
+				SolveRiddleFunc: func(spec *service.RiddleSpec) (*service.RiddleResponse, *service.AppError) {
+					if !spec.Total || spec.Z != 8 {
+						return nil, &service.AppError{
+							Error:   errors.New("unexpected spec"),
+							Message: "some message",
+							Code:    http.StatusInternalServerError,
+						}
+					}
+					return &service.RiddleResponse{
+						Operations: []service.Operation{
+							{
+								OperationType: "type",
+								Jug:           aws.String(spec.Names[0]),
+								WaterAmount:   spec.Capacities[0],
+								Step:          1,
+								Description:   "description",
+							},
+						},
+						TotalSteps: 1,
+						Levels:     map[string]int{spec.Names[0]: spec.Capacities[0], spec.Names[1]: 0},
+						Optimal:    true,
+					}, nil
+				},
+			},
+			args: args{
+				x:     "3",
+				y:     "5",
+				z:     "8",
+				total: "true",
+			},
+			status: http.StatusOK,
+			response: &service.RiddleResponse{
+				Operations: []service.Operation{
+					{
+						OperationType: "type",
+						Jug:           aws.String("x"),
+						WaterAmount:   3,
+						Step:          1,
+						Description:   "description",
+					},
+				},
+				TotalSteps: 1,
+				Levels:     map[string]int{"x": 3, "y": 0},
+				Optimal:    true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "ok with cursor",
+			svc: &ServiceMock{
+				RiddlePageFunc: func(x int, y int, z int, cursor string, limit int) (*service.RiddleResponse, *service.AppError) {
+					return &service.RiddleResponse{
+						Operations: []service.Operation{
+							{
+								OperationType: "type",
+								Jug:           aws.String("x"),
+								WaterAmount:   limit,
+								Step:          2,
+								Description:   cursor,
+							},
+						},
+						Jug:        "x",
+						TotalSteps: 3,
+						NextCursor: "Mw",
+					}, nil
+				},
+			},
+			args: args{
+				x:      "1",
+				y:      "1",
+				z:      "1",
+				cursor: "Mg",
+			},
+			status: http.StatusOK,
+			response: &service.RiddleResponse{
+				Operations: []service.Operation{
+					{
+						OperationType: "type",
+						Jug:           aws.String("x"),
+						WaterAmount:   50,
+						Step:          2,
+						Description:   "Mg",
+					},
+				},
+				Jug:        "x",
+				TotalSteps: 3,
+				NextCursor: "Mw",
+			},
+			wantErr: false,
+		},
+		{
+			name: "ok compact",
+			svc: &ServiceMock{
+				StreamRiddleFunc: func(x int, y int, z int, emit func(service.Operation) error) (*service.RiddleResponse, *service.AppError) {
+					for step := 1; step <= 4; step++ {
+						if err := emit(service.Operation{
+							OperationType: "type",
+							Jug:           aws.String("x"),
+							WaterAmount:   1,
+							Step:          step,
+						}); err != nil {
+							return nil, &service.AppError{Error: err}
+						}
+					}
+					return &service.RiddleResponse{
+						Jug:        "x",
+						TotalSteps: 4,
+					}, nil
+				},
+			},
+			args: args{
+				x:      "1",
+				y:      "1",
+				z:      "1",
+				format: "compact",
+			},
+			status: http.StatusOK,
+			response: &service.RiddleResponse{
+				Blocks: []service.OperationBlock{
+					{
+						Step:   1,
+						Repeat: 4,
+						Operations: []service.Operation{
+							{
+								OperationType: "type",
+								Jug:           aws.String("x"),
+								WaterAmount:   1,
+							},
+						},
+					},
+				},
+				Jug:        "x",
+				TotalSteps: 4,
+			},
+			wantErr: false,
+		},
+		{
+			name: "ok compact optimal",
+			svc: &ServiceMock{
+				OptimalRiddleFunc: func(x int, y int, z int) (*service.RiddleResponse, *service.AppError) {
+					return &service.RiddleResponse{
+						Operations: []service.Operation{
+							{
+								OperationType: "type",
+								Jug:           aws.String("x"),
+								WaterAmount:   1,
+								Step:          1,
+							},
+						},
+						Jug:        "x",
+						TotalSteps: 1,
+						Optimal:    true,
+					}, nil
+				},
+			},
+			args: args{
+				x:       "1",
+				y:       "1",
+				z:       "1",
+				optimal: "true",
+				format:  "compact",
+			},
+			status: http.StatusOK,
+			response: &service.RiddleResponse{
+				Blocks: []service.OperationBlock{
+					{
+						Step:   1,
+						Repeat: 1,
+						Operations: []service.Operation{
+							{
+								OperationType: "type",
+								Jug:           aws.String("x"),
+								WaterAmount:   1,
+							},
+						},
+					},
+				},
+				Jug:        "x",
+				TotalSteps: 1,
+				Optimal:    true,
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -190,14 +522,24 @@ func TestHandler(t *testing.T) {
 			r := httptest.NewRequest(
 				http.MethodGet,
 				fmt.Sprintf(
-					"%s?%s=%s&%s=%s&%s=%s",
+					"%s?%s=%s&%s=%s&%s=%s&%s=%s&%s=%s&%s=%s&%s=%s&%s=%s",
 					riddleEndpoint,
 					xQueryParam,
 					tt.args.x,
 					yQueryParam,
 					tt.args.y,
 					zQueryParam,
-					tt.args.z),
+					tt.args.z,
+					optimalQueryParam,
+					tt.args.optimal,
+					totalQueryParam,
+					tt.args.total,
+					limitQueryParam,
+					tt.args.limit,
+					cursorQueryParam,
+					tt.args.cursor,
+					formatQueryParam,
+					tt.args.format),
 				nil)
 			h.ServeHTTP(w, r)
 
@@ -4,13 +4,29 @@
 package controller
 
 import (
+	"math/big"
 	"sync"
 	"water-jug-riddle-service/service"
 )
 
 var (
-	lockServiceMockHealth sync.RWMutex
-	lockServiceMockRiddle sync.RWMutex
+	lockServiceMockBigRiddle       sync.RWMutex
+	lockServiceMockCountRiddle     sync.RWMutex
+	lockServiceMockDesignJugs      sync.RWMutex
+	lockServiceMockHealth          sync.RWMutex
+	lockServiceMockInferCapacities sync.RWMutex
+	lockServiceMockMixRiddle       sync.RWMutex
+	lockServiceMockNearestRiddle   sync.RWMutex
+	lockServiceMockOptimalRiddle   sync.RWMutex
+	lockServiceMockOracleRiddle    sync.RWMutex
+	lockServiceMockParetoRiddle    sync.RWMutex
+	lockServiceMockRecipeRiddle    sync.RWMutex
+	lockServiceMockRiddle          sync.RWMutex
+	lockServiceMockRiddlePage      sync.RWMutex
+	lockServiceMockScheduleRiddle  sync.RWMutex
+	lockServiceMockShareRiddle     sync.RWMutex
+	lockServiceMockSolveRiddle     sync.RWMutex
+	lockServiceMockStreamRiddle    sync.RWMutex
 )
 
 // Ensure, that ServiceMock does implement service.Service.
@@ -23,12 +39,57 @@ var _ service.Service = &ServiceMock{}
 //
 //         // make and configure a mocked service.Service
 //         mockedService := &ServiceMock{
+//             BigRiddleFunc: func(x *big.Int, y *big.Int, z *big.Int) (*service.BigRiddleResponse, *service.AppError) {
+// 	               panic("mock out the BigRiddle method")
+//             },
+//             CountRiddleFunc: func(spec *service.RiddleSpec, limit int) (*service.SolutionsResponse, *service.AppError) {
+// 	               panic("mock out the CountRiddle method")
+//             },
+//             DesignJugsFunc: func(spec *service.DesignSpec, limit int) (*service.DesignResponse, *service.AppError) {
+// 	               panic("mock out the DesignJugs method")
+//             },
 //             HealthFunc: func() *service.HealthResponse {
 // 	               panic("mock out the Health method")
 //             },
+//             InferCapacitiesFunc: func(spec *service.InferSpec) (*service.InferResponse, *service.AppError) {
+// 	               panic("mock out the InferCapacities method")
+//             },
+//             MixRiddleFunc: func(spec *service.MixSpec) (*service.RiddleResponse, *service.AppError) {
+// 	               panic("mock out the MixRiddle method")
+//             },
+//             NearestRiddleFunc: func(x int, y int, z int, maxSteps *int) (*service.NearestResponse, *service.AppError) {
+// 	               panic("mock out the NearestRiddle method")
+//             },
+//             OptimalRiddleFunc: func(x int, y int, z int) (*service.RiddleResponse, *service.AppError) {
+// 	               panic("mock out the OptimalRiddle method")
+//             },
+//             OracleRiddleFunc: func(x int, y int, z int, step *int) (*service.OracleResponse, *service.AppError) {
+// 	               panic("mock out the OracleRiddle method")
+//             },
+//             ParetoRiddleFunc: func(spec *service.RiddleSpec) (*service.ParetoResponse, *service.AppError) {
+// 	               panic("mock out the ParetoRiddle method")
+//             },
+//             RecipeRiddleFunc: func(spec *service.RecipeSpec) (*service.RiddleResponse, *service.AppError) {
+// 	               panic("mock out the RecipeRiddle method")
+//             },
 //             RiddleFunc: func(x int, y int, z int) (*service.RiddleResponse, *service.AppError) {
 // 	               panic("mock out the Riddle method")
 //             },
+//             RiddlePageFunc: func(x int, y int, z int, cursor string, limit int) (*service.RiddleResponse, *service.AppError) {
+// 	               panic("mock out the RiddlePage method")
+//             },
+//             ScheduleRiddleFunc: func(spec *service.ScheduleSpec) (*service.ScheduleResponse, *service.AppError) {
+// 	               panic("mock out the ScheduleRiddle method")
+//             },
+//             ShareRiddleFunc: func(spec *service.ShareSpec) (*service.RiddleResponse, *service.AppError) {
+// 	               panic("mock out the ShareRiddle method")
+//             },
+//             SolveRiddleFunc: func(spec *service.RiddleSpec) (*service.RiddleResponse, *service.AppError) {
+// 	               panic("mock out the SolveRiddle method")
+//             },
+//             StreamRiddleFunc: func(x int, y int, z int, emit func(service.Operation) error) (*service.RiddleResponse, *service.AppError) {
+// 	               panic("mock out the StreamRiddle method")
+//             },
 //         }
 //
 //         // use mockedService in code that requires service.Service
@@ -36,17 +97,136 @@ var _ service.Service = &ServiceMock{}
 //
 //     }
 type ServiceMock struct {
+	// BigRiddleFunc mocks the BigRiddle method.
+	BigRiddleFunc func(x *big.Int, y *big.Int, z *big.Int) (*service.BigRiddleResponse, *service.AppError)
+
+	// CountRiddleFunc mocks the CountRiddle method.
+	CountRiddleFunc func(spec *service.RiddleSpec, limit int) (*service.SolutionsResponse, *service.AppError)
+
+	// DesignJugsFunc mocks the DesignJugs method.
+	DesignJugsFunc func(spec *service.DesignSpec, limit int) (*service.DesignResponse, *service.AppError)
+
 	// HealthFunc mocks the Health method.
 	HealthFunc func() *service.HealthResponse
 
+	// InferCapacitiesFunc mocks the InferCapacities method.
+	InferCapacitiesFunc func(spec *service.InferSpec) (*service.InferResponse, *service.AppError)
+
+	// MixRiddleFunc mocks the MixRiddle method.
+	MixRiddleFunc func(spec *service.MixSpec) (*service.RiddleResponse, *service.AppError)
+
+	// NearestRiddleFunc mocks the NearestRiddle method.
+	NearestRiddleFunc func(x int, y int, z int, maxSteps *int) (*service.NearestResponse, *service.AppError)
+
+	// OptimalRiddleFunc mocks the OptimalRiddle method.
+	OptimalRiddleFunc func(x int, y int, z int) (*service.RiddleResponse, *service.AppError)
+
+	// OracleRiddleFunc mocks the OracleRiddle method.
+	OracleRiddleFunc func(x int, y int, z int, step *int) (*service.OracleResponse, *service.AppError)
+
+	// ParetoRiddleFunc mocks the ParetoRiddle method.
+	ParetoRiddleFunc func(spec *service.RiddleSpec) (*service.ParetoResponse, *service.AppError)
+
+	// RecipeRiddleFunc mocks the RecipeRiddle method.
+	RecipeRiddleFunc func(spec *service.RecipeSpec) (*service.RiddleResponse, *service.AppError)
+
 	// RiddleFunc mocks the Riddle method.
 	RiddleFunc func(x int, y int, z int) (*service.RiddleResponse, *service.AppError)
 
+	// RiddlePageFunc mocks the RiddlePage method.
+	RiddlePageFunc func(x int, y int, z int, cursor string, limit int) (*service.RiddleResponse, *service.AppError)
+
+	// ScheduleRiddleFunc mocks the ScheduleRiddle method.
+	ScheduleRiddleFunc func(spec *service.ScheduleSpec) (*service.ScheduleResponse, *service.AppError)
+
+	// ShareRiddleFunc mocks the ShareRiddle method.
+	ShareRiddleFunc func(spec *service.ShareSpec) (*service.RiddleResponse, *service.AppError)
+
+	// SolveRiddleFunc mocks the SolveRiddle method.
+	SolveRiddleFunc func(spec *service.RiddleSpec) (*service.RiddleResponse, *service.AppError)
+
+	// StreamRiddleFunc mocks the StreamRiddle method.
+	StreamRiddleFunc func(x int, y int, z int, emit func(service.Operation) error) (*service.RiddleResponse, *service.AppError)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// BigRiddle holds details about calls to the BigRiddle method.
+		BigRiddle []struct {
+			// X is the x argument value.
+			X *big.Int
+			// Y is the y argument value.
+			Y *big.Int
+			// Z is the z argument value.
+			Z *big.Int
+		}
+		// CountRiddle holds details about calls to the CountRiddle method.
+		CountRiddle []struct {
+			// Spec is the spec argument value.
+			Spec *service.RiddleSpec
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// DesignJugs holds details about calls to the DesignJugs method.
+		DesignJugs []struct {
+			// Spec is the spec argument value.
+			Spec *service.DesignSpec
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// Health holds details about calls to the Health method.
 		Health []struct {
 		}
+		// InferCapacities holds details about calls to the InferCapacities method.
+		InferCapacities []struct {
+			// Spec is the spec argument value.
+			Spec *service.InferSpec
+		}
+		// MixRiddle holds details about calls to the MixRiddle method.
+		MixRiddle []struct {
+			// Spec is the spec argument value.
+			Spec *service.MixSpec
+		}
+		// NearestRiddle holds details about calls to the NearestRiddle method.
+		NearestRiddle []struct {
+			// X is the x argument value.
+			X int
+			// Y is the y argument value.
+			Y int
+			// Z is the z argument value.
+			Z int
+			// MaxSteps is the maxSteps argument value.
+			MaxSteps *int
+		}
+		// OptimalRiddle holds details about calls to the OptimalRiddle method.
+		OptimalRiddle []struct {
+			// X is the x argument value.
+			X int
+			// Y is the y argument value.
+			Y int
+			// Z is the z argument value.
+			Z int
+		}
+		// OracleRiddle holds details about calls to the OracleRiddle method.
+		OracleRiddle []struct {
+			// X is the x argument value.
+			X int
+			// Y is the y argument value.
+			Y int
+			// Z is the z argument value.
+			Z int
+			// Step is the step argument value.
+			Step *int
+		}
+		// ParetoRiddle holds details about calls to the ParetoRiddle method.
+		ParetoRiddle []struct {
+			// Spec is the spec argument value.
+			Spec *service.RiddleSpec
+		}
+		// RecipeRiddle holds details about calls to the RecipeRiddle method.
+		RecipeRiddle []struct {
+			// Spec is the spec argument value.
+			Spec *service.RecipeSpec
+		}
 		// Riddle holds details about calls to the Riddle method.
 		Riddle []struct {
 			// X is the x argument value.
@@ -56,7 +236,155 @@ type ServiceMock struct {
 			// Z is the z argument value.
 			Z int
 		}
+		// RiddlePage holds details about calls to the RiddlePage method.
+		RiddlePage []struct {
+			// X is the x argument value.
+			X int
+			// Y is the y argument value.
+			Y int
+			// Z is the z argument value.
+			Z int
+			// Cursor is the cursor argument value.
+			Cursor string
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// ScheduleRiddle holds details about calls to the ScheduleRiddle method.
+		ScheduleRiddle []struct {
+			// Spec is the spec argument value.
+			Spec *service.ScheduleSpec
+		}
+		// ShareRiddle holds details about calls to the ShareRiddle method.
+		ShareRiddle []struct {
+			// Spec is the spec argument value.
+			Spec *service.ShareSpec
+		}
+		// SolveRiddle holds details about calls to the SolveRiddle method.
+		SolveRiddle []struct {
+			// Spec is the spec argument value.
+			Spec *service.RiddleSpec
+		}
+		// StreamRiddle holds details about calls to the StreamRiddle method.
+		StreamRiddle []struct {
+			// X is the x argument value.
+			X int
+			// Y is the y argument value.
+			Y int
+			// Z is the z argument value.
+			Z int
+			// Emit is the emit argument value.
+			Emit func(service.Operation) error
+		}
+	}
+}
+
+// BigRiddle calls BigRiddleFunc.
+func (mock *ServiceMock) BigRiddle(x *big.Int, y *big.Int, z *big.Int) (*service.BigRiddleResponse, *service.AppError) {
+	if mock.BigRiddleFunc == nil {
+		panic("ServiceMock.BigRiddleFunc: method is nil but Service.BigRiddle was just called")
+	}
+	callInfo := struct {
+		X *big.Int
+		Y *big.Int
+		Z *big.Int
+	}{
+		X: x,
+		Y: y,
+		Z: z,
+	}
+	lockServiceMockBigRiddle.Lock()
+	mock.calls.BigRiddle = append(mock.calls.BigRiddle, callInfo)
+	lockServiceMockBigRiddle.Unlock()
+	return mock.BigRiddleFunc(x, y, z)
+}
+
+// BigRiddleCalls gets all the calls that were made to BigRiddle.
+// Check the length with:
+//     len(mockedService.BigRiddleCalls())
+func (mock *ServiceMock) BigRiddleCalls() []struct {
+	X *big.Int
+	Y *big.Int
+	Z *big.Int
+} {
+	var calls []struct {
+		X *big.Int
+		Y *big.Int
+		Z *big.Int
+	}
+	lockServiceMockBigRiddle.RLock()
+	calls = mock.calls.BigRiddle
+	lockServiceMockBigRiddle.RUnlock()
+	return calls
+}
+
+// CountRiddle calls CountRiddleFunc.
+func (mock *ServiceMock) CountRiddle(spec *service.RiddleSpec, limit int) (*service.SolutionsResponse, *service.AppError) {
+	if mock.CountRiddleFunc == nil {
+		panic("ServiceMock.CountRiddleFunc: method is nil but Service.CountRiddle was just called")
+	}
+	callInfo := struct {
+		Spec  *service.RiddleSpec
+		Limit int
+	}{
+		Spec:  spec,
+		Limit: limit,
+	}
+	lockServiceMockCountRiddle.Lock()
+	mock.calls.CountRiddle = append(mock.calls.CountRiddle, callInfo)
+	lockServiceMockCountRiddle.Unlock()
+	return mock.CountRiddleFunc(spec, limit)
+}
+
+// CountRiddleCalls gets all the calls that were made to CountRiddle.
+// Check the length with:
+//     len(mockedService.CountRiddleCalls())
+func (mock *ServiceMock) CountRiddleCalls() []struct {
+	Spec  *service.RiddleSpec
+	Limit int
+} {
+	var calls []struct {
+		Spec  *service.RiddleSpec
+		Limit int
+	}
+	lockServiceMockCountRiddle.RLock()
+	calls = mock.calls.CountRiddle
+	lockServiceMockCountRiddle.RUnlock()
+	return calls
+}
+
+// DesignJugs calls DesignJugsFunc.
+func (mock *ServiceMock) DesignJugs(spec *service.DesignSpec, limit int) (*service.DesignResponse, *service.AppError) {
+	if mock.DesignJugsFunc == nil {
+		panic("ServiceMock.DesignJugsFunc: method is nil but Service.DesignJugs was just called")
+	}
+	callInfo := struct {
+		Spec  *service.DesignSpec
+		Limit int
+	}{
+		Spec:  spec,
+		Limit: limit,
+	}
+	lockServiceMockDesignJugs.Lock()
+	mock.calls.DesignJugs = append(mock.calls.DesignJugs, callInfo)
+	lockServiceMockDesignJugs.Unlock()
+	return mock.DesignJugsFunc(spec, limit)
+}
+
+// DesignJugsCalls gets all the calls that were made to DesignJugs.
+// Check the length with:
+//     len(mockedService.DesignJugsCalls())
+func (mock *ServiceMock) DesignJugsCalls() []struct {
+	Spec  *service.DesignSpec
+	Limit int
+} {
+	var calls []struct {
+		Spec  *service.DesignSpec
+		Limit int
 	}
+	lockServiceMockDesignJugs.RLock()
+	calls = mock.calls.DesignJugs
+	lockServiceMockDesignJugs.RUnlock()
+	return calls
 }
 
 // Health calls HealthFunc.
@@ -85,6 +413,255 @@ func (mock *ServiceMock) HealthCalls() []struct {
 	return calls
 }
 
+// InferCapacities calls InferCapacitiesFunc.
+func (mock *ServiceMock) InferCapacities(spec *service.InferSpec) (*service.InferResponse, *service.AppError) {
+	if mock.InferCapacitiesFunc == nil {
+		panic("ServiceMock.InferCapacitiesFunc: method is nil but Service.InferCapacities was just called")
+	}
+	callInfo := struct {
+		Spec *service.InferSpec
+	}{
+		Spec: spec,
+	}
+	lockServiceMockInferCapacities.Lock()
+	mock.calls.InferCapacities = append(mock.calls.InferCapacities, callInfo)
+	lockServiceMockInferCapacities.Unlock()
+	return mock.InferCapacitiesFunc(spec)
+}
+
+// InferCapacitiesCalls gets all the calls that were made to InferCapacities.
+// Check the length with:
+//     len(mockedService.InferCapacitiesCalls())
+func (mock *ServiceMock) InferCapacitiesCalls() []struct {
+	Spec *service.InferSpec
+} {
+	var calls []struct {
+		Spec *service.InferSpec
+	}
+	lockServiceMockInferCapacities.RLock()
+	calls = mock.calls.InferCapacities
+	lockServiceMockInferCapacities.RUnlock()
+	return calls
+}
+
+// MixRiddle calls MixRiddleFunc.
+func (mock *ServiceMock) MixRiddle(spec *service.MixSpec) (*service.RiddleResponse, *service.AppError) {
+	if mock.MixRiddleFunc == nil {
+		panic("ServiceMock.MixRiddleFunc: method is nil but Service.MixRiddle was just called")
+	}
+	callInfo := struct {
+		Spec *service.MixSpec
+	}{
+		Spec: spec,
+	}
+	lockServiceMockMixRiddle.Lock()
+	mock.calls.MixRiddle = append(mock.calls.MixRiddle, callInfo)
+	lockServiceMockMixRiddle.Unlock()
+	return mock.MixRiddleFunc(spec)
+}
+
+// MixRiddleCalls gets all the calls that were made to MixRiddle.
+// Check the length with:
+//     len(mockedService.MixRiddleCalls())
+func (mock *ServiceMock) MixRiddleCalls() []struct {
+	Spec *service.MixSpec
+} {
+	var calls []struct {
+		Spec *service.MixSpec
+	}
+	lockServiceMockMixRiddle.RLock()
+	calls = mock.calls.MixRiddle
+	lockServiceMockMixRiddle.RUnlock()
+	return calls
+}
+
+// NearestRiddle calls NearestRiddleFunc.
+func (mock *ServiceMock) NearestRiddle(x int, y int, z int, maxSteps *int) (*service.NearestResponse, *service.AppError) {
+	if mock.NearestRiddleFunc == nil {
+		panic("ServiceMock.NearestRiddleFunc: method is nil but Service.NearestRiddle was just called")
+	}
+	callInfo := struct {
+		X        int
+		Y        int
+		Z        int
+		MaxSteps *int
+	}{
+		X:        x,
+		Y:        y,
+		Z:        z,
+		MaxSteps: maxSteps,
+	}
+	lockServiceMockNearestRiddle.Lock()
+	mock.calls.NearestRiddle = append(mock.calls.NearestRiddle, callInfo)
+	lockServiceMockNearestRiddle.Unlock()
+	return mock.NearestRiddleFunc(x, y, z, maxSteps)
+}
+
+// NearestRiddleCalls gets all the calls that were made to NearestRiddle.
+// Check the length with:
+//     len(mockedService.NearestRiddleCalls())
+func (mock *ServiceMock) NearestRiddleCalls() []struct {
+	X        int
+	Y        int
+	Z        int
+	MaxSteps *int
+} {
+	var calls []struct {
+		X        int
+		Y        int
+		Z        int
+		MaxSteps *int
+	}
+	lockServiceMockNearestRiddle.RLock()
+	calls = mock.calls.NearestRiddle
+	lockServiceMockNearestRiddle.RUnlock()
+	return calls
+}
+
+// OptimalRiddle calls OptimalRiddleFunc.
+func (mock *ServiceMock) OptimalRiddle(x int, y int, z int) (*service.RiddleResponse, *service.AppError) {
+	if mock.OptimalRiddleFunc == nil {
+		panic("ServiceMock.OptimalRiddleFunc: method is nil but Service.OptimalRiddle was just called")
+	}
+	callInfo := struct {
+		X int
+		Y int
+		Z int
+	}{
+		X: x,
+		Y: y,
+		Z: z,
+	}
+	lockServiceMockOptimalRiddle.Lock()
+	mock.calls.OptimalRiddle = append(mock.calls.OptimalRiddle, callInfo)
+	lockServiceMockOptimalRiddle.Unlock()
+	return mock.OptimalRiddleFunc(x, y, z)
+}
+
+// OptimalRiddleCalls gets all the calls that were made to OptimalRiddle.
+// Check the length with:
+//     len(mockedService.OptimalRiddleCalls())
+func (mock *ServiceMock) OptimalRiddleCalls() []struct {
+	X int
+	Y int
+	Z int
+} {
+	var calls []struct {
+		X int
+		Y int
+		Z int
+	}
+	lockServiceMockOptimalRiddle.RLock()
+	calls = mock.calls.OptimalRiddle
+	lockServiceMockOptimalRiddle.RUnlock()
+	return calls
+}
+
+// OracleRiddle calls OracleRiddleFunc.
+func (mock *ServiceMock) OracleRiddle(x int, y int, z int, step *int) (*service.OracleResponse, *service.AppError) {
+	if mock.OracleRiddleFunc == nil {
+		panic("ServiceMock.OracleRiddleFunc: method is nil but Service.OracleRiddle was just called")
+	}
+	callInfo := struct {
+		X    int
+		Y    int
+		Z    int
+		Step *int
+	}{
+		X:    x,
+		Y:    y,
+		Z:    z,
+		Step: step,
+	}
+	lockServiceMockOracleRiddle.Lock()
+	mock.calls.OracleRiddle = append(mock.calls.OracleRiddle, callInfo)
+	lockServiceMockOracleRiddle.Unlock()
+	return mock.OracleRiddleFunc(x, y, z, step)
+}
+
+// OracleRiddleCalls gets all the calls that were made to OracleRiddle.
+// Check the length with:
+//     len(mockedService.OracleRiddleCalls())
+func (mock *ServiceMock) OracleRiddleCalls() []struct {
+	X    int
+	Y    int
+	Z    int
+	Step *int
+} {
+	var calls []struct {
+		X    int
+		Y    int
+		Z    int
+		Step *int
+	}
+	lockServiceMockOracleRiddle.RLock()
+	calls = mock.calls.OracleRiddle
+	lockServiceMockOracleRiddle.RUnlock()
+	return calls
+}
+
+// ParetoRiddle calls ParetoRiddleFunc.
+func (mock *ServiceMock) ParetoRiddle(spec *service.RiddleSpec) (*service.ParetoResponse, *service.AppError) {
+	if mock.ParetoRiddleFunc == nil {
+		panic("ServiceMock.ParetoRiddleFunc: method is nil but Service.ParetoRiddle was just called")
+	}
+	callInfo := struct {
+		Spec *service.RiddleSpec
+	}{
+		Spec: spec,
+	}
+	lockServiceMockParetoRiddle.Lock()
+	mock.calls.ParetoRiddle = append(mock.calls.ParetoRiddle, callInfo)
+	lockServiceMockParetoRiddle.Unlock()
+	return mock.ParetoRiddleFunc(spec)
+}
+
+// ParetoRiddleCalls gets all the calls that were made to ParetoRiddle.
+// Check the length with:
+//     len(mockedService.ParetoRiddleCalls())
+func (mock *ServiceMock) ParetoRiddleCalls() []struct {
+	Spec *service.RiddleSpec
+} {
+	var calls []struct {
+		Spec *service.RiddleSpec
+	}
+	lockServiceMockParetoRiddle.RLock()
+	calls = mock.calls.ParetoRiddle
+	lockServiceMockParetoRiddle.RUnlock()
+	return calls
+}
+
+// RecipeRiddle calls RecipeRiddleFunc.
+func (mock *ServiceMock) RecipeRiddle(spec *service.RecipeSpec) (*service.RiddleResponse, *service.AppError) {
+	if mock.RecipeRiddleFunc == nil {
+		panic("ServiceMock.RecipeRiddleFunc: method is nil but Service.RecipeRiddle was just called")
+	}
+	callInfo := struct {
+		Spec *service.RecipeSpec
+	}{
+		Spec: spec,
+	}
+	lockServiceMockRecipeRiddle.Lock()
+	mock.calls.RecipeRiddle = append(mock.calls.RecipeRiddle, callInfo)
+	lockServiceMockRecipeRiddle.Unlock()
+	return mock.RecipeRiddleFunc(spec)
+}
+
+// RecipeRiddleCalls gets all the calls that were made to RecipeRiddle.
+// Check the length with:
+//     len(mockedService.RecipeRiddleCalls())
+func (mock *ServiceMock) RecipeRiddleCalls() []struct {
+	Spec *service.RecipeSpec
+} {
+	var calls []struct {
+		Spec *service.RecipeSpec
+	}
+	lockServiceMockRecipeRiddle.RLock()
+	calls = mock.calls.RecipeRiddle
+	lockServiceMockRecipeRiddle.RUnlock()
+	return calls
+}
+
 // Riddle calls RiddleFunc.
 func (mock *ServiceMock) Riddle(x int, y int, z int) (*service.RiddleResponse, *service.AppError) {
 	if mock.RiddleFunc == nil {
@@ -123,3 +700,186 @@ func (mock *ServiceMock) RiddleCalls() []struct {
 	lockServiceMockRiddle.RUnlock()
 	return calls
 }
+
+// RiddlePage calls RiddlePageFunc.
+func (mock *ServiceMock) RiddlePage(x int, y int, z int, cursor string, limit int) (*service.RiddleResponse, *service.AppError) {
+	if mock.RiddlePageFunc == nil {
+		panic("ServiceMock.RiddlePageFunc: method is nil but Service.RiddlePage was just called")
+	}
+	callInfo := struct {
+		X      int
+		Y      int
+		Z      int
+		Cursor string
+		Limit  int
+	}{
+		X:      x,
+		Y:      y,
+		Z:      z,
+		Cursor: cursor,
+		Limit:  limit,
+	}
+	lockServiceMockRiddlePage.Lock()
+	mock.calls.RiddlePage = append(mock.calls.RiddlePage, callInfo)
+	lockServiceMockRiddlePage.Unlock()
+	return mock.RiddlePageFunc(x, y, z, cursor, limit)
+}
+
+// RiddlePageCalls gets all the calls that were made to RiddlePage.
+// Check the length with:
+//     len(mockedService.RiddlePageCalls())
+func (mock *ServiceMock) RiddlePageCalls() []struct {
+	X      int
+	Y      int
+	Z      int
+	Cursor string
+	Limit  int
+} {
+	var calls []struct {
+		X      int
+		Y      int
+		Z      int
+		Cursor string
+		Limit  int
+	}
+	lockServiceMockRiddlePage.RLock()
+	calls = mock.calls.RiddlePage
+	lockServiceMockRiddlePage.RUnlock()
+	return calls
+}
+
+// ScheduleRiddle calls ScheduleRiddleFunc.
+func (mock *ServiceMock) ScheduleRiddle(spec *service.ScheduleSpec) (*service.ScheduleResponse, *service.AppError) {
+	if mock.ScheduleRiddleFunc == nil {
+		panic("ServiceMock.ScheduleRiddleFunc: method is nil but Service.ScheduleRiddle was just called")
+	}
+	callInfo := struct {
+		Spec *service.ScheduleSpec
+	}{
+		Spec: spec,
+	}
+	lockServiceMockScheduleRiddle.Lock()
+	mock.calls.ScheduleRiddle = append(mock.calls.ScheduleRiddle, callInfo)
+	lockServiceMockScheduleRiddle.Unlock()
+	return mock.ScheduleRiddleFunc(spec)
+}
+
+// ScheduleRiddleCalls gets all the calls that were made to ScheduleRiddle.
+// Check the length with:
+//     len(mockedService.ScheduleRiddleCalls())
+func (mock *ServiceMock) ScheduleRiddleCalls() []struct {
+	Spec *service.ScheduleSpec
+} {
+	var calls []struct {
+		Spec *service.ScheduleSpec
+	}
+	lockServiceMockScheduleRiddle.RLock()
+	calls = mock.calls.ScheduleRiddle
+	lockServiceMockScheduleRiddle.RUnlock()
+	return calls
+}
+
+// ShareRiddle calls ShareRiddleFunc.
+func (mock *ServiceMock) ShareRiddle(spec *service.ShareSpec) (*service.RiddleResponse, *service.AppError) {
+	if mock.ShareRiddleFunc == nil {
+		panic("ServiceMock.ShareRiddleFunc: method is nil but Service.ShareRiddle was just called")
+	}
+	callInfo := struct {
+		Spec *service.ShareSpec
+	}{
+		Spec: spec,
+	}
+	lockServiceMockShareRiddle.Lock()
+	mock.calls.ShareRiddle = append(mock.calls.ShareRiddle, callInfo)
+	lockServiceMockShareRiddle.Unlock()
+	return mock.ShareRiddleFunc(spec)
+}
+
+// ShareRiddleCalls gets all the calls that were made to ShareRiddle.
+// Check the length with:
+//     len(mockedService.ShareRiddleCalls())
+func (mock *ServiceMock) ShareRiddleCalls() []struct {
+	Spec *service.ShareSpec
+} {
+	var calls []struct {
+		Spec *service.ShareSpec
+	}
+	lockServiceMockShareRiddle.RLock()
+	calls = mock.calls.ShareRiddle
+	lockServiceMockShareRiddle.RUnlock()
+	return calls
+}
+
+// SolveRiddle calls SolveRiddleFunc.
+func (mock *ServiceMock) SolveRiddle(spec *service.RiddleSpec) (*service.RiddleResponse, *service.AppError) {
+	if mock.SolveRiddleFunc == nil {
+		panic("ServiceMock.SolveRiddleFunc: method is nil but Service.SolveRiddle was just called")
+	}
+	callInfo := struct {
+		Spec *service.RiddleSpec
+	}{
+		Spec: spec,
+	}
+	lockServiceMockSolveRiddle.Lock()
+	mock.calls.SolveRiddle = append(mock.calls.SolveRiddle, callInfo)
+	lockServiceMockSolveRiddle.Unlock()
+	return mock.SolveRiddleFunc(spec)
+}
+
+// SolveRiddleCalls gets all the calls that were made to SolveRiddle.
+// Check the length with:
+//     len(mockedService.SolveRiddleCalls())
+func (mock *ServiceMock) SolveRiddleCalls() []struct {
+	Spec *service.RiddleSpec
+} {
+	var calls []struct {
+		Spec *service.RiddleSpec
+	}
+	lockServiceMockSolveRiddle.RLock()
+	calls = mock.calls.SolveRiddle
+	lockServiceMockSolveRiddle.RUnlock()
+	return calls
+}
+
+// StreamRiddle calls StreamRiddleFunc.
+func (mock *ServiceMock) StreamRiddle(x int, y int, z int, emit func(service.Operation) error) (*service.RiddleResponse, *service.AppError) {
+	if mock.StreamRiddleFunc == nil {
+		panic("ServiceMock.StreamRiddleFunc: method is nil but Service.StreamRiddle was just called")
+	}
+	callInfo := struct {
+		X    int
+		Y    int
+		Z    int
+		Emit func(service.Operation) error
+	}{
+		X:    x,
+		Y:    y,
+		Z:    z,
+		Emit: emit,
+	}
+	lockServiceMockStreamRiddle.Lock()
+	mock.calls.StreamRiddle = append(mock.calls.StreamRiddle, callInfo)
+	lockServiceMockStreamRiddle.Unlock()
+	return mock.StreamRiddleFunc(x, y, z, emit)
+}
+
+// StreamRiddleCalls gets all the calls that were made to StreamRiddle.
+// Check the length with:
+//     len(mockedService.StreamRiddleCalls())
+func (mock *ServiceMock) StreamRiddleCalls() []struct {
+	X    int
+	Y    int
+	Z    int
+	Emit func(service.Operation) error
+} {
+	var calls []struct {
+		X    int
+		Y    int
+		Z    int
+		Emit func(service.Operation) error
+	}
+	lockServiceMockStreamRiddle.RLock()
+	calls = mock.calls.StreamRiddle
+	lockServiceMockStreamRiddle.RUnlock()
+	return calls
+}
@@ -1,5 +1,7 @@
 package service
 
+import "math/big"
+
 type AppError struct {
 	Error   error
 	Message string
@@ -12,6 +14,37 @@ type Service interface {
 	Health() *HealthResponse
 	// Riddle: Solves Water Jug Riddle
 	Riddle(x, y, z int) (*RiddleResponse, *AppError)
+	// OptimalRiddle: Solves Water Jug Riddle with the shortest possible plan
+	OptimalRiddle(x, y, z int) (*RiddleResponse, *AppError)
+	// SolveRiddle: Solves Water Jug Riddle with any number of jugs
+	SolveRiddle(spec *RiddleSpec) (*RiddleResponse, *AppError)
+	// ParetoRiddle: Finds every plan that trades off steps, water used and water wasted
+	ParetoRiddle(spec *RiddleSpec) (*ParetoResponse, *AppError)
+	// OracleRiddle: Describes the solution of Riddle, and its state at any step, without generating it
+	OracleRiddle(x, y, z int, step *int) (*OracleResponse, *AppError)
+	// BigRiddle: Solves Water Jug Riddle with arbitrary-precision capacities
+	BigRiddle(x, y, z *big.Int) (*BigRiddleResponse, *AppError)
+	// StreamRiddle: Solves Water Jug Riddle passing every operation to emit as soon as it's generated, returning only
+	// the jug and total steps
+	StreamRiddle(x, y, z int, emit func(Operation) error) (*RiddleResponse, *AppError)
+	// RiddlePage: Solves Water Jug Riddle returning up to limit operations, starting at cursor
+	RiddlePage(x, y, z int, cursor string, limit int) (*RiddleResponse, *AppError)
+	// CountRiddle: Counts every shortest plan of Water Jug Riddle with any number of jugs, listing up to limit of them
+	CountRiddle(spec *RiddleSpec, limit int) (*SolutionsResponse, *AppError)
+	// ShareRiddle: Shares water between jugs only by pouring it, until it reaches the goal distribution
+	ShareRiddle(spec *ShareSpec) (*RiddleResponse, *AppError)
+	// MixRiddle: Mixes the liquids of two taps until a jug holds some volume at some concentration
+	MixRiddle(spec *MixSpec) (*RiddleResponse, *AppError)
+	// ScheduleRiddle: Schedules the plan of Water Jug Riddle in time, with several workers performing operations at once
+	ScheduleRiddle(spec *ScheduleSpec) (*ScheduleResponse, *AppError)
+	// NearestRiddle: Suggests the amounts closest to z that can be measured, within maxSteps when it's not nil
+	NearestRiddle(x, y, z int, maxSteps *int) (*NearestResponse, *AppError)
+	// DesignJugs: Finds the pairs of jugs that measure some amounts in the fewest steps, returning up to limit of them
+	DesignJugs(spec *DesignSpec, limit int) (*DesignResponse, *AppError)
+	// InferCapacities: Infers the capacities of the jugs from a log of the operations performed with them
+	InferCapacities(spec *InferSpec) (*InferResponse, *AppError)
+	// RecipeRiddle: Measures several amounts one after another in a single plan, delivering each of them
+	RecipeRiddle(spec *RecipeSpec) (*RiddleResponse, *AppError)
 }
 
 type service struct {
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+)
+
+// maxListedSteps is the maximum amount of steps of a solution whose operations are listed
+const maxListedSteps = 10000
+
+// BezoutIdentity holds the coefficients of x and y so that x*X + y*Y = Value
+type BezoutIdentity struct {
+	X     *big.Int `json:"x"`
+	Y     *big.Int `json:"y"`
+	Value *big.Int `json:"value"`
+}
+
+type BigRiddleResponse struct {
+	// Operations is only present when the solution is small enough to be listed
+	Operations []Operation `json:"operations,omitempty"`
+	Jug        string      `json:"jug,omitempty"`
+	TotalSteps *big.Int    `json:"total_steps,omitempty"`
+	// Gcd holds the greatest common divisor of x and y, together with its Bézout coefficients
+	Gcd *BezoutIdentity `json:"gcd,omitempty"`
+	// Solution holds how many times the solution fills and empties each jug, as the coefficients of z
+	Solution *BezoutIdentity `json:"solution,omitempty"`
+}
+
+// bigPourOracle is the same as pourOracle, using arbitrary-precision integers
+type bigPourOracle struct {
+	jug1Cap *big.Int
+	jug1Tag string
+	jug2Cap *big.Int
+	jug2Tag string
+	lcm     *big.Int
+	// final is the event where z is measured, or 0 when the first fill already measures it
+	final *big.Int
+	jug   string
+}
+
+func newBigPourOracle(jug1Cap *big.Int, jug1Tag string, jug2Cap *big.Int, jug2Tag string, z *big.Int) *bigPourOracle {
+	g := new(big.Int).GCD(nil, nil, jug1Cap, jug2Cap)
+	jug1Units := new(big.Int).Quo(jug1Cap, g)
+	jug2Units := new(big.Int).Quo(jug2Cap, g)
+
+	o := &bigPourOracle{
+		jug1Cap: jug1Cap,
+		jug1Tag: jug1Tag,
+		jug2Cap: jug2Cap,
+		jug2Tag: jug2Tag,
+		lcm:     new(big.Int).Mul(jug1Units, jug2Cap),
+		final:   new(big.Int),
+	}
+
+	if z.Cmp(jug1Cap) == 0 {
+		o.jug = jug1Tag
+		return o
+	}
+
+	// z is measured in jug1 after a pour that filled jug2, i.e. on a multiple of jug2Cap that is -z modulo jug1Cap
+	if z.Cmp(jug1Cap) < 0 {
+		i := new(big.Int).ModInverse(jug2Units, jug1Units)
+		i.Mul(i, new(big.Int).Quo(new(big.Int).Sub(jug1Cap, z), g))
+		i.Mod(i, jug1Units)
+		o.final.Mul(jug2Cap, i)
+		o.jug = jug1Tag
+	}
+
+	// z is measured in jug2 on the first event that is z modulo jug2Cap. Unless z is jug2Cap, it's a multiple of jug1Cap
+	if z.Cmp(jug2Cap) <= 0 {
+		event := new(big.Int).Set(jug2Cap)
+		if z.Cmp(jug2Cap) < 0 {
+			i := new(big.Int).ModInverse(jug1Units, jug2Units)
+			i.Mul(i, new(big.Int).Quo(z, g))
+			i.Mod(i, jug2Units)
+			event.Mul(jug1Cap, i)
+		}
+		if o.final.Sign() == 0 || event.Cmp(o.final) < 0 {
+			o.final = event
+			o.jug = jug2Tag
+		}
+	}
+
+	return o
+}
+
+// fills returns how many times jug1 is filled
+func (o *bigPourOracle) fills() *big.Int {
+	if o.final.Sign() == 0 {
+		return big.NewInt(1)
+	}
+	fills := new(big.Int).Add(o.final, o.jug1Cap)
+	fills.Sub(fills, big.NewInt(1))
+	return fills.Quo(fills, o.jug1Cap)
+}
+
+// empties returns how many times jug2 is emptied
+func (o *bigPourOracle) empties() *big.Int {
+	if o.final.Sign() == 0 {
+		return new(big.Int)
+	}
+	empties := new(big.Int).Sub(o.final, big.NewInt(1))
+	return empties.Quo(empties, o.jug2Cap)
+}
+
+func (o *bigPourOracle) totalSteps() *big.Int {
+	if o.final.Sign() == 0 {
+		return big.NewInt(1)
+	}
+
+	pours := new(big.Int).Quo(o.final, o.jug1Cap)
+	pours.Add(pours, new(big.Int).Quo(o.final, o.jug2Cap))
+	pours.Sub(pours, new(big.Int).Quo(o.final, o.lcm))
+
+	// Every fill but the first one, and every empty, happen after a pour
+	steps := new(big.Int).Add(pours, o.fills())
+	return steps.Add(steps, o.empties())
+}
+
+// solution returns how many times each jug is filled (positive) and emptied (negative), so that the water in both jugs
+// is x*X + y*Y. When z is measured in jug1, jug2 is full and must be emptied once more to leave only z
+func (o *bigPourOracle) solution(z *big.Int) *BezoutIdentity {
+	empties := new(big.Int).Neg(o.empties())
+	if o.final.Sign() > 0 && o.jug == o.jug1Tag {
+		empties.Sub(empties, big.NewInt(1))
+	}
+
+	identity := &BezoutIdentity{
+		X:     o.fills(),
+		Y:     empties,
+		Value: z,
+	}
+	if o.jug1Tag == yJugTag {
+		identity.X, identity.Y = identity.Y, identity.X
+	}
+	return identity
+}
+
+func (s *service) BigRiddle(x, y, z *big.Int) (*BigRiddleResponse, *AppError) {
+	if err := validateBigRiddle(x, y, z); err != nil {
+		return nil, err
+	}
+
+	smallerJug, smallerJugTag := x, xJugTag
+	biggerJug, biggerJugTag := y, yJugTag
+	if x.Cmp(y) > 0 {
+		smallerJug, smallerJugTag = y, yJugTag
+		biggerJug, biggerJugTag = x, xJugTag
+	}
+
+	// Same as getOperations, the first scenario is kept only when it's shorter
+	oracle := newBigPourOracle(smallerJug, smallerJugTag, biggerJug, biggerJugTag, z)
+	if first := newBigPourOracle(biggerJug, biggerJugTag, smallerJug, smallerJugTag, z); first.totalSteps().Cmp(
+		oracle.totalSteps()) < 0 {
+		oracle = first
+	}
+
+	gcdX, gcdY := new(big.Int), new(big.Int)
+	g := new(big.Int).GCD(gcdX, gcdY, x, y)
+
+	response := &BigRiddleResponse{
+		Jug:        oracle.jug,
+		TotalSteps: oracle.totalSteps(),
+		Gcd: &BezoutIdentity{
+			X:     gcdX,
+			Y:     gcdY,
+			Value: g,
+		},
+		Solution: oracle.solution(z),
+	}
+
+	if response.TotalSteps.Cmp(big.NewInt(maxListedSteps)) <= 0 {
+		if !fitsInt(x) || !fitsInt(y) || !fitsInt(z) {
+			return nil, &AppError{
+				Error:   fmt.Errorf("can't list the operations of jugs that don't fit in %d bits", strconv.IntSize),
+				Message: "invalid parameters",
+				Code:    http.StatusBadRequest,
+			}
+		}
+		operations, _, err := s.getOperations(int(x.Int64()), int(y.Int64()), int(z.Int64()))
+		if err != nil {
+			return nil, err
+		}
+		response.Operations = operations
+	}
+
+	return response, nil
+}
+
+// fitsInt tells whether value can be converted to an int of the platform without truncating it
+func fitsInt(value *big.Int) bool {
+	return value.IsInt64() && value.BitLen() < strconv.IntSize
+}
+
+// validateBigRiddle checks that z can be measured with jugs of x and y capacity, same as validateRiddle
+func validateBigRiddle(x, y, z *big.Int) *AppError {
+	smallerJug, biggerJug := x, y
+	if x.Cmp(y) > 0 {
+		smallerJug, biggerJug = y, x
+	}
+
+	if z.Cmp(biggerJug) > 0 {
+		return &AppError{
+			Error:   fmt.Errorf("can't measure %s if it's bigger than jugs for %s and %s", z, smallerJug, biggerJug),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	// If gcd of smaller jug and bigger jug does not divide z, then solution is not possible
+	calculatedGcd := new(big.Int).GCD(nil, nil, smallerJug, biggerJug)
+	if new(big.Int).Mod(z, calculatedGcd).Sign() != 0 {
+		return &AppError{
+			Error:   fmt.Errorf("there is no solution to measure %s with jugs with %s and %s", z, smallerJug, biggerJug),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return nil
+}
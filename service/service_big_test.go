@@ -0,0 +1,139 @@
+package service
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func bigInt(s string) *big.Int {
+	value, _ := new(big.Int).SetString(s, 10)
+	return value
+}
+
+func TestService_BigRiddle(t *testing.T) {
+	type args struct {
+		x *big.Int
+		y *big.Int
+		z *big.Int
+	}
+	type want struct {
+		output    *BigRiddleResponse
+		outputErr *AppError
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "z is bigger than x and y",
+			args: args{
+				x: bigInt("100000000000000000000"),
+				y: bigInt("200000000000000000000"),
+				z: bigInt("300000000000000000000"),
+			},
+			want: want{
+				outputErr: &AppError{
+					Error: fmt.Errorf("can't measure %s if it's bigger than jugs for %s and %s",
+						"300000000000000000000", "100000000000000000000", "200000000000000000000"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "gcd of smaller jug and bigger jug doesn't divide z",
+			args: args{
+				x: bigInt("200000000000000000000"),
+				y: bigInt("100000000000000000000"),
+				z: bigInt("3"),
+			},
+			want: want{
+				outputErr: &AppError{
+					Error: fmt.Errorf("there is no solution to measure %s with jugs with %s and %s",
+						"3", "100000000000000000000", "200000000000000000000"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "jugs too big to list a short solution",
+			args: args{
+				x: bigInt("1"),
+				y: bigInt("100000000000000000000000000000"),
+				z: bigInt("1"),
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("can't list the operations of jugs that don't fit in %d bits", strconv.IntSize),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "success summarising a solution too large to list",
+			args: args{
+				x: bigInt("100000000000000000000000000000"),
+				y: bigInt("100000000000000000000000000007"),
+				z: bigInt("1"),
+			},
+			want: want{
+				output: &BigRiddleResponse{
+					Jug:        xJugTag,
+					TotalSteps: bigInt("171428571428571428571428571432"),
+					Gcd: &BezoutIdentity{
+						X:     bigInt("42857142857142857142857142860"),
+						Y:     bigInt("-42857142857142857142857142857"),
+						Value: bigInt("1"),
+					},
+					Solution: &BezoutIdentity{
+						X:     bigInt("42857142857142857142857142860"),
+						Y:     bigInt("-42857142857142857142857142857"),
+						Value: bigInt("1"),
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &service{}
+			output, outputErr := svc.BigRiddle(tt.args.x, tt.args.y, tt.args.z)
+
+			a := assert.New(t)
+			a.Equal(tt.want.output, output)
+			a.Equal(tt.want.outputErr, outputErr)
+		})
+	}
+}
+
+func TestService_BigRiddle_MatchesRiddle(t *testing.T) {
+	svc := &service{}
+	a := assert.New(t)
+
+	for x := 1; x <= 12; x++ {
+		for y := 1; y <= 12; y++ {
+			for z := 1; z <= 12; z++ {
+				riddle, riddleErr := svc.Riddle(x, y, z)
+				output, outputErr := svc.BigRiddle(big.NewInt(int64(x)), big.NewInt(int64(y)), big.NewInt(int64(z)))
+				if riddleErr != nil {
+					a.NotNil(outputErr, "x = %d, y = %d and z = %d", x, y, z)
+					continue
+				}
+				a.Equal(riddle.Operations, output.Operations, "x = %d, y = %d and z = %d", x, y, z)
+				a.Equal(riddle.Jug, output.Jug, "x = %d, y = %d and z = %d", x, y, z)
+				a.Equal(int64(riddle.TotalSteps), output.TotalSteps.Int64(), "x = %d, y = %d and z = %d", x, y, z)
+
+				solution := x*int(output.Solution.X.Int64()) + y*int(output.Solution.Y.Int64())
+				a.Equal(z, solution, "x = %d, y = %d and z = %d", x, y, z)
+			}
+		}
+	}
+}
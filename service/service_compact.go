@@ -0,0 +1,150 @@
+package service
+
+import (
+	"reflect"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// maxBlockPeriod is the maximum amount of operations of a block that is repeated
+const maxBlockPeriod = 16
+
+// OperationBlock is a sequence of operations that is performed repeat times in a row, starting at step. Operations
+// don't include their step, as it's known from their position
+type OperationBlock struct {
+	Step       int         `json:"step"`
+	Repeat     int         `json:"repeat"`
+	Operations []Operation `json:"operations"`
+}
+
+// BlockCompressor groups operations into blocks as they are added, so that a plan can be compressed while it's
+// generated. Operations that don't repeat are grouped into blocks performed once
+type BlockCompressor struct {
+	blocks []OperationBlock
+	// pending contains the last operations, which may still start a new repeated block
+	pending []Operation
+	step    int
+}
+
+func NewBlockCompressor() *BlockCompressor {
+	return &BlockCompressor{step: 1}
+}
+
+// Add adds the next operation of the plan. It never fails, so that it can be used to emit operations
+func (c *BlockCompressor) Add(operation Operation) error {
+	operation.Step = 0
+	c.pending = append(c.pending, operation)
+
+	// The pending operations may be another repetition of the last block
+	if last := c.lastBlock(); last != nil && last.Repeat > 1 && sameOperations(last.Operations, c.pending) {
+		last.Repeat++
+		c.step += len(c.pending)
+		c.pending = nil
+		return nil
+	}
+
+	// Or they may end with the same operations twice, which start a new block
+	n := len(c.pending)
+	for period := 1; period <= maxBlockPeriod && 2*period <= n; period++ {
+		if sameOperations(c.pending[n-2*period:n-period], c.pending[n-period:]) {
+			c.addOnce(c.pending[:n-2*period]...)
+			c.blocks = append(c.blocks, OperationBlock{
+				Step:       c.step,
+				Repeat:     2,
+				Operations: append([]Operation(nil), c.pending[n-period:]...),
+			})
+			c.step += 2 * period
+			c.pending = nil
+			return nil
+		}
+	}
+
+	// The oldest pending operation can't be part of a repetition anymore
+	if n > 2*maxBlockPeriod {
+		c.addOnce(c.pending[0])
+		c.pending = c.pending[1:]
+	}
+	return nil
+}
+
+// Blocks returns the blocks of every operation added so far
+func (c *BlockCompressor) Blocks() []OperationBlock {
+	c.addOnce(c.pending...)
+	c.pending = nil
+	return c.blocks
+}
+
+// addOnce adds operations that are performed once, together with the previous ones if they are performed once too
+func (c *BlockCompressor) addOnce(operations ...Operation) {
+	if len(operations) == 0 {
+		return
+	}
+
+	if last := c.lastBlock(); last != nil && last.Repeat == 1 {
+		last.Operations = append(last.Operations, operations...)
+	} else {
+		c.blocks = append(c.blocks, OperationBlock{
+			Step:       c.step,
+			Repeat:     1,
+			Operations: append([]Operation(nil), operations...),
+		})
+	}
+	c.step += len(operations)
+}
+
+func (c *BlockCompressor) lastBlock() *OperationBlock {
+	if len(c.blocks) == 0 {
+		return nil
+	}
+	return &c.blocks[len(c.blocks)-1]
+}
+
+// CompressOperations groups the operations of a plan into blocks
+func CompressOperations(operations []Operation) []OperationBlock {
+	compressor := NewBlockCompressor()
+	for _, operation := range operations {
+		_ = compressor.Add(operation)
+	}
+	return compressor.Blocks()
+}
+
+// ExpandBlocks returns the flat list of operations of a plan grouped into blocks, numbering their steps
+func ExpandBlocks(blocks []OperationBlock) []Operation {
+	var operations []Operation
+	step := 1
+	for _, block := range blocks {
+		for i := 0; i < block.Repeat; i++ {
+			for _, operation := range block.Operations {
+				operation.Step = step
+				operations = append(operations, operation)
+				step++
+			}
+		}
+	}
+	return operations
+}
+
+func sameOperations(a, b []Operation) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !sameOperation(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameOperation compares every field of both operations but their step
+func sameOperation(a, b Operation) bool {
+	return a.OperationType == b.OperationType &&
+		aws.StringValue(a.Jug) == aws.StringValue(b.Jug) &&
+		aws.StringValue(a.JugOrigin) == aws.StringValue(b.JugOrigin) &&
+		aws.StringValue(a.JugDestination) == aws.StringValue(b.JugDestination) &&
+		a.WaterAmount == b.WaterAmount &&
+		a.Description == b.Description &&
+		a.Cost == b.Cost &&
+		a.Mark == b.Mark &&
+		reflect.DeepEqual(a.Composition, b.Composition)
+}
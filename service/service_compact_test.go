@@ -0,0 +1,115 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompressOperations(t *testing.T) {
+	svc := &service{}
+	riddle, _ := svc.Riddle(2, 9, 1)
+
+	blocks := CompressOperations(riddle.Operations)
+
+	a := assert.New(t)
+	a.Equal([]OperationBlock{
+		{
+			Step:   1,
+			Repeat: 1,
+			Operations: []Operation{
+				{
+					OperationType: operationTypeFill,
+					Jug:           aws.String(yJugTag),
+					WaterAmount:   9,
+					Description:   fmt.Sprintf("filling jug %s with 9 capacity", yJugTag),
+				},
+			},
+		},
+		{
+			Step:   2,
+			Repeat: 3,
+			Operations: []Operation{
+				{
+					OperationType:  operationTypePour,
+					JugOrigin:      aws.String(yJugTag),
+					JugDestination: aws.String(xJugTag),
+					WaterAmount:    2,
+					Description:    fmt.Sprintf("pouring water from jug %s to %s", yJugTag, xJugTag),
+				},
+				{
+					OperationType: operationTypeEmpty,
+					Jug:           aws.String(xJugTag),
+					WaterAmount:   2,
+					Description:   fmt.Sprintf("emptying jug %s with 2 capacity", xJugTag),
+				},
+			},
+		},
+		{
+			Step:   8,
+			Repeat: 1,
+			Operations: []Operation{
+				{
+					OperationType:  operationTypePour,
+					JugOrigin:      aws.String(yJugTag),
+					JugDestination: aws.String(xJugTag),
+					WaterAmount:    2,
+					Description:    fmt.Sprintf("pouring water from jug %s to %s", yJugTag, xJugTag),
+				},
+			},
+		},
+	}, blocks)
+	a.Equal(riddle.Operations, ExpandBlocks(blocks))
+}
+
+func TestCompressOperations_ExpandsBack(t *testing.T) {
+	svc := &service{}
+	a := assert.New(t)
+
+	for x := 1; x <= 20; x++ {
+		for y := 1; y <= 20; y++ {
+			for z := 1; z <= 20; z++ {
+				riddle, err := svc.Riddle(x, y, z)
+				if err != nil {
+					continue
+				}
+				a.Equal(riddle.Operations, ExpandBlocks(CompressOperations(riddle.Operations)),
+					"x = %d, y = %d and z = %d", x, y, z)
+			}
+		}
+	}
+}
+
+func TestBlockCompressor_HugeSolution(t *testing.T) {
+	svc := &service{}
+	compressor := NewBlockCompressor()
+
+	output, outputErr := svc.StreamRiddle(1, 1000000, 500000, compressor.Add)
+
+	a := assert.New(t)
+	a.Nil(outputErr)
+	a.Equal(1000000, output.TotalSteps)
+	a.Equal([]OperationBlock{
+		{
+			Step:   1,
+			Repeat: 500000,
+			Operations: []Operation{
+				{
+					OperationType: operationTypeFill,
+					Jug:           aws.String(xJugTag),
+					WaterAmount:   1,
+					Description:   fmt.Sprintf("filling jug %s with 1 capacity", xJugTag),
+				},
+				{
+					OperationType:  operationTypePour,
+					JugOrigin:      aws.String(xJugTag),
+					JugDestination: aws.String(yJugTag),
+					WaterAmount:    1,
+					Description:    fmt.Sprintf("pouring water from jug %s to %s", xJugTag, yJugTag),
+				},
+			},
+		},
+	}, compressor.Blocks())
+}
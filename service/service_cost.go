@@ -0,0 +1,47 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+)
+
+// OperationCost is the cost of performing one operation, optionally scaled by the water it moves
+type OperationCost struct {
+	Base    float64 `json:"base,omitempty"`
+	PerUnit float64 `json:"per_unit,omitempty"`
+}
+
+// defaultOperationCost is the cost of operations without a cost, so that they count as a step
+var defaultOperationCost = OperationCost{Base: 1}
+
+// operationCosts returns the function that prices each operation. Operations without a cost count as one step
+func operationCosts(costs map[OperationType]OperationCost) func(Operation) float64 {
+	return func(operation Operation) float64 {
+		cost, ok := costs[operation.OperationType]
+		if !ok {
+			cost = defaultOperationCost
+		}
+		return cost.Base + cost.PerUnit*float64(operation.WaterAmount)
+	}
+}
+
+func validateOperationCosts(costs map[OperationType]OperationCost) error {
+	for operationType, cost := range costs {
+		if err := validateOperationType(operationType); err != nil {
+			return err
+		}
+		if cost.Base < 0 || cost.PerUnit < 0 {
+			return errors.New("costs can't be negative")
+		}
+	}
+	return nil
+}
+
+func validateOperationType(operationType OperationType) error {
+	switch operationType {
+	case operationTypeFill, operationTypeEmpty, operationTypePour, operationTypeTilt:
+		return nil
+	default:
+		return fmt.Errorf("unknown operation %s", operationType)
+	}
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// maxDesignCapacity is the biggest capacity of the jugs designed, as every pair of capacities up to it is tried
+	maxDesignCapacity = 1000
+	// maxDesignAmounts is the most amounts a design can measure, as every pair of capacities is tried with each of them
+	maxDesignAmounts = 10
+)
+
+// DesignSpec describes the amounts that a pair of jugs must be able to measure
+type DesignSpec struct {
+	// Amounts contains every amount the jugs must measure
+	Amounts []int `json:"amounts,omitempty"`
+	// MaxCapacity is the biggest capacity a jug can have
+	MaxCapacity int `json:"max_capacity,omitempty"`
+}
+
+// JugDesign is a pair of capacities that can measure every amount, with the steps Riddle takes to measure each of them
+type JugDesign struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	// WorstSteps is the most steps taken to measure any of the amounts
+	WorstSteps int `json:"worst_steps"`
+	// TotalSteps is the sum of the steps taken to measure every amount
+	TotalSteps int              `json:"total_steps"`
+	Amounts    []DesignedAmount `json:"amounts"`
+}
+
+type DesignedAmount struct {
+	Amount     int    `json:"amount"`
+	Jug        string `json:"jug"`
+	TotalSteps int    `json:"total_steps"`
+}
+
+type DesignResponse struct {
+	Designs []JugDesign `json:"designs,omitempty"`
+}
+
+func (s *service) DesignJugs(spec *DesignSpec, limit int) (*DesignResponse, *AppError) {
+	if err := validateDesignSpec(spec, limit); err != nil {
+		return nil, err
+	}
+
+	biggest := maxOf(spec.Amounts...)
+	divisor := gcdOf(spec.Amounts...)
+
+	// Only the best designs found so far are kept, the worst of them on top to be replaced by a better one
+	best := &designHeap{}
+	for y := biggest; y <= spec.MaxCapacity; y++ {
+		for x := 1; x <= y; x++ {
+			// Both jugs only measure the multiples of their gcd, so it must divide every amount
+			if divisor%gcd(x, y) != 0 {
+				continue
+			}
+			design, ok := designJugs(x, y, spec.Amounts)
+			if !ok {
+				continue
+			}
+			if best.Len() < limit {
+				heap.Push(best, design)
+			} else if betterDesign(design, best.designs[0]) {
+				best.designs[0] = design
+				heap.Fix(best, 0)
+			}
+		}
+	}
+
+	// A jug of 1 measures anything, so there is always some design
+	designs := make([]JugDesign, best.Len())
+	for i := len(designs) - 1; i >= 0; i-- {
+		designs[i] = heap.Pop(best).(JugDesign)
+	}
+
+	return &DesignResponse{
+		Designs: designs,
+	}, nil
+}
+
+// betterDesign tells whether a takes fewer steps for the hardest amount than b, then for every amount, or else whether
+// its jugs are smaller
+func betterDesign(a, b JugDesign) bool {
+	if a.WorstSteps != b.WorstSteps {
+		return a.WorstSteps < b.WorstSteps
+	}
+	if a.TotalSteps != b.TotalSteps {
+		return a.TotalSteps < b.TotalSteps
+	}
+	if a.Y != b.Y {
+		return a.Y < b.Y
+	}
+	return a.X < b.X
+}
+
+// designHeap is a priority queue of designs with the worst one on top
+type designHeap struct {
+	designs []JugDesign
+}
+
+func (h *designHeap) Len() int { return len(h.designs) }
+
+func (h *designHeap) Less(i, j int) bool { return betterDesign(h.designs[j], h.designs[i]) }
+
+func (h *designHeap) Swap(i, j int) { h.designs[i], h.designs[j] = h.designs[j], h.designs[i] }
+
+func (h *designHeap) Push(x interface{}) {
+	h.designs = append(h.designs, x.(JugDesign))
+}
+
+func (h *designHeap) Pop() interface{} {
+	last := len(h.designs) - 1
+	design := h.designs[last]
+	h.designs = h.designs[:last]
+	return design
+}
+
+// designJugs returns the steps Riddle takes to measure every amount with jugs with x and y, which are known without
+// generating the solutions, and whether every amount can be measured
+func designJugs(x, y int, amounts []int) (JugDesign, bool) {
+	design := JugDesign{X: x, Y: y}
+	for _, amount := range amounts {
+		oracle, err := getOracle(x, y, amount)
+		if err != nil {
+			return design, false
+		}
+
+		steps := oracle.totalSteps()
+		design.WorstSteps = max(design.WorstSteps, steps)
+		design.TotalSteps += steps
+		design.Amounts = append(design.Amounts, DesignedAmount{
+			Amount:     amount,
+			Jug:        oracle.jug,
+			TotalSteps: steps,
+		})
+	}
+	return design, true
+}
+
+func validateDesignSpec(spec *DesignSpec, limit int) *AppError {
+	if limit < 1 {
+		return &AppError{
+			Error:   errors.New("limit must be positive"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if len(spec.Amounts) == 0 {
+		return &AppError{
+			Error:   errors.New("at least one amount is required"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if len(spec.Amounts) > maxDesignAmounts {
+		return &AppError{
+			Error:   fmt.Errorf("can't design jugs for more than %d amounts", maxDesignAmounts),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if spec.MaxCapacity > maxDesignCapacity {
+		return &AppError{
+			Error:   fmt.Errorf("max capacity can't be bigger than %d", maxDesignCapacity),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if biggest := maxOf(spec.Amounts...); biggest > spec.MaxCapacity {
+		return &AppError{
+			Error:   fmt.Errorf("can't measure %d if it's bigger than jugs up to %d", biggest, spec.MaxCapacity),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_DesignJugs(t *testing.T) {
+	type input struct {
+		spec  *DesignSpec
+		limit int
+	}
+	type want struct {
+		output    *DesignResponse
+		outputErr *AppError
+	}
+	tests := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			name:  "limit is zero",
+			input: input{spec: &DesignSpec{Amounts: []int{4}, MaxCapacity: 6}},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("limit must be positive"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name:  "amounts are missing",
+			input: input{spec: &DesignSpec{MaxCapacity: 6}, limit: 1},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("at least one amount is required"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name:  "too many amounts",
+			input: input{spec: &DesignSpec{Amounts: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, MaxCapacity: 20}, limit: 1},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("can't design jugs for more than %d amounts", maxDesignAmounts),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name:  "max capacity is too big",
+			input: input{spec: &DesignSpec{Amounts: []int{2}, MaxCapacity: 1001}, limit: 1},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("max capacity can't be bigger than %d", maxDesignCapacity),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name:  "amount is bigger than max capacity",
+			input: input{spec: &DesignSpec{Amounts: []int{2, 7}, MaxCapacity: 6}, limit: 1},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("can't measure 7 if it's bigger than jugs up to 6"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name:  "success with the designs of fewest worst-case steps",
+			input: input{spec: &DesignSpec{Amounts: []int{1, 2, 4}, MaxCapacity: 6}, limit: 2},
+			want: want{
+				output: &DesignResponse{
+					Designs: []JugDesign{
+						{
+							X:          1,
+							Y:          4,
+							WorstSteps: 4,
+							TotalSteps: 6,
+							Amounts: []DesignedAmount{
+								{Amount: 1, Jug: xJugTag, TotalSteps: 1},
+								{Amount: 2, Jug: yJugTag, TotalSteps: 4},
+								{Amount: 4, Jug: yJugTag, TotalSteps: 1},
+							},
+						},
+						{
+							X:          3,
+							Y:          4,
+							WorstSteps: 4,
+							TotalSteps: 7,
+							Amounts: []DesignedAmount{
+								{Amount: 1, Jug: yJugTag, TotalSteps: 2},
+								{Amount: 2, Jug: xJugTag, TotalSteps: 4},
+								{Amount: 4, Jug: yJugTag, TotalSteps: 1},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "success leaving out jugs whose gcd doesn't divide the amounts",
+			input: input{spec: &DesignSpec{Amounts: []int{2, 4}, MaxCapacity: 4}, limit: 1},
+			want: want{
+				output: &DesignResponse{
+					Designs: []JugDesign{
+						{
+							X:          2,
+							Y:          4,
+							WorstSteps: 1,
+							TotalSteps: 2,
+							Amounts: []DesignedAmount{
+								{Amount: 2, Jug: xJugTag, TotalSteps: 1},
+								{Amount: 4, Jug: yJugTag, TotalSteps: 1},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &service{}
+			output, outputErr := svc.DesignJugs(tt.input.spec, tt.input.limit)
+
+			a := assert.New(t)
+			a.Equal(tt.want.output, output)
+			a.Equal(tt.want.outputErr, outputErr)
+		})
+	}
+}
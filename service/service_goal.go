@@ -0,0 +1,91 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RiddleGoal describes the exact state of jugs where the riddle is solved
+type RiddleGoal struct {
+	// Jug is the tag of the jug that must hold z
+	Jug string `json:"jug,omitempty"`
+	// Levels contains the amount of water some jugs must hold, by tag. Jugs missing from it can hold any amount
+	Levels map[string]int `json:"levels,omitempty"`
+}
+
+// accepts returns the check for the states that match the goal, given the index of every jug by tag
+func (g *RiddleGoal) accepts(indexes map[string]int, z int) func(jugState) bool {
+	levels := map[int]int{}
+	for tag, level := range g.Levels {
+		levels[indexes[tag]] = level
+	}
+	if g.Jug != "" {
+		levels[indexes[g.Jug]] = z
+	}
+
+	return func(state jugState) bool {
+		for i, level := range levels {
+			if state[i] != level {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// describe returns the amount of water in every jug, such as "x=2, y=4"
+func (s jugState) describe(tags []string) string {
+	levels := make([]string, len(s))
+	for i, level := range s {
+		levels[i] = fmt.Sprintf("%s=%d", tags[i], level)
+	}
+	return strings.Join(levels, ", ")
+}
+
+func validateRiddleGoal(spec *RiddleSpec, tags []string) error {
+	goal := spec.Goal
+	if goal.Jug == "" && len(goal.Levels) == 0 {
+		return errors.New("goal needs a jug or levels")
+	}
+
+	indexes := map[string]int{}
+	for i, tag := range tags {
+		indexes[tag] = i
+	}
+
+	if goal.Jug != "" {
+		if _, ok := indexes[goal.Jug]; !ok {
+			return fmt.Errorf("unknown jug %s", goal.Jug)
+		}
+		if spec.Total {
+			return errors.New("goal jug can't be used together with total")
+		}
+		if spec.Z == 0 {
+			return fmt.Errorf("z is required to measure it in jug %s", goal.Jug)
+		}
+	}
+
+	if level, ok := goal.Levels[goal.Jug]; ok && level != spec.Z {
+		return fmt.Errorf("jug %s can't hold both %d and %d", goal.Jug, level, spec.Z)
+	}
+	return validateGoalLevels(goal.Levels, spec.Capacities, tags)
+}
+
+func validateGoalLevels(levels map[string]int, capacities []int, tags []string) error {
+	indexes := map[string]int{}
+	for i, tag := range tags {
+		indexes[tag] = i
+	}
+
+	for tag, level := range levels {
+		i, ok := indexes[tag]
+		if !ok {
+			return fmt.Errorf("unknown jug %s", tag)
+		}
+		if level < 0 || level > capacities[i] {
+			return fmt.Errorf("level of jug %s must be between 0 and %d", tag, capacities[i])
+		}
+	}
+	return nil
+}
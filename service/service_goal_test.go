@@ -0,0 +1,207 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_SolveRiddle_Goal(t *testing.T) {
+	type want struct {
+		jug        string
+		totalSteps int
+		levels     map[string]int
+		outputErr  *AppError
+	}
+	tests := []struct {
+		name string
+		spec *RiddleSpec
+		want want
+	}{
+		{
+			name: "goal is empty",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Z:          4,
+				Goal:       &RiddleGoal{},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("goal needs a jug or levels"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "goal jug is unknown",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Names:      []string{xJugTag, yJugTag},
+				Z:          4,
+				Goal:       &RiddleGoal{Jug: "w"},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("unknown jug w"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "goal jug measuring the total",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Names:      []string{xJugTag, yJugTag},
+				Z:          4,
+				Total:      true,
+				Goal:       &RiddleGoal{Jug: yJugTag},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("goal jug can't be used together with total"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "goal jug without z",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Names:      []string{xJugTag, yJugTag},
+				Goal:       &RiddleGoal{Jug: yJugTag, Levels: map[string]int{xJugTag: 0}},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("z is required to measure it in jug %s", yJugTag),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "goal level is bigger than its jug",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Names:      []string{xJugTag, yJugTag},
+				Goal:       &RiddleGoal{Levels: map[string]int{xJugTag: 4}},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("level of jug %s must be between 0 and 3", xJugTag),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "goal jug holding a different level than z",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Names:      []string{xJugTag, yJugTag},
+				Z:          4,
+				Goal:       &RiddleGoal{Jug: yJugTag, Levels: map[string]int{yJugTag: 2}},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("jug %s can't hold both 2 and 4", yJugTag),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "gcd of every jug doesn't divide a goal level",
+			spec: &RiddleSpec{
+				Capacities: []int{2, 4},
+				Names:      []string{xJugTag, yJugTag},
+				Goal:       &RiddleGoal{Levels: map[string]int{xJugTag: 1}},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("there is no solution to reach the goal with jugs with %v", []int{2, 4}),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "goal levels can't be reached",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Names:      []string{xJugTag, yJugTag},
+				Goal:       &RiddleGoal{Levels: map[string]int{xJugTag: 2, yJugTag: 4}},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("there is no solution to reach the goal with jugs with %v", []int{3, 5}),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "success with the level of every jug",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Names:      []string{xJugTag, yJugTag},
+				Goal:       &RiddleGoal{Levels: map[string]int{xJugTag: 3, yJugTag: 5}},
+			},
+			want: want{
+				jug:        "x=3, y=5",
+				totalSteps: 2,
+				levels:     map[string]int{xJugTag: 3, yJugTag: 5},
+			},
+		},
+		{
+			name: "success with z in a jug and the other one empty",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Names:      []string{xJugTag, yJugTag},
+				Z:          4,
+				Goal:       &RiddleGoal{Jug: yJugTag, Levels: map[string]int{xJugTag: 0}},
+			},
+			want: want{
+				jug:        "x=0, y=4",
+				totalSteps: 7,
+				levels:     map[string]int{xJugTag: 0, yJugTag: 4},
+			},
+		},
+		{
+			name: "success with z in a specific jug",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Names:      []string{xJugTag, yJugTag},
+				Z:          1,
+				Goal:       &RiddleGoal{Jug: xJugTag},
+			},
+			want: want{
+				jug:        "x=1, y=5",
+				totalSteps: 4,
+				levels:     map[string]int{xJugTag: 1, yJugTag: 5},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &service{}
+			output, outputErr := svc.SolveRiddle(tt.spec)
+
+			a := assert.New(t)
+			a.Equal(tt.want.outputErr, outputErr)
+			if tt.want.outputErr != nil {
+				return
+			}
+
+			a.Equal(tt.want.jug, output.Jug)
+			a.Equal(tt.want.totalSteps, output.TotalSteps)
+			a.Equal(tt.want.levels, output.Levels)
+			a.True(output.Optimal)
+		})
+	}
+}
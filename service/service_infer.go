@@ -0,0 +1,299 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// defaultInferJugs is the number of jugs a log is performed with when it's not given
+	defaultInferJugs = 2
+	maxInferJugs     = 4
+	// maxInferences is the most assignments of operations to jugs that are kept before giving up
+	maxInferences = 100000
+)
+
+// InferSpec describes a log of operations performed on jugs of unknown capacity, starting with every jug empty
+type InferSpec struct {
+	// Operations contains the operations in the order they were performed. Only their type and amount are observed
+	Operations []Operation `json:"operations,omitempty"`
+	// Jugs is the number of jugs the log can be performed with. There are two when it's missing
+	Jugs int `json:"jugs,omitempty"`
+}
+
+// InferResponse contains every set of jugs the log can be performed with
+type InferResponse struct {
+	// Candidates contains the jugs of every consistent assignment of the operations to jugs, leaving out the jugs
+	// that are never used. Jugs are sorted by capacity, as their labels are unknown
+	Candidates [][]InferredJug `json:"candidates"`
+}
+
+type InferredJug struct {
+	// Capacity is the capacity of the jug, when the log tells it exactly
+	Capacity int `json:"capacity,omitempty"`
+	// MinCapacity is the least capacity of a jug the log doesn't tell exactly, as it's never full
+	MinCapacity int `json:"min_capacity,omitempty"`
+	// Level is the level of the jug at the end of the log
+	Level int `json:"level"`
+}
+
+/*
+ inference is an assignment of the operations replayed so far to jugs, keeping the level of every jug. Fills and
+      tilts leave a level that tells the capacity of the jug, and empties and pours move the amount observed.
+      Capacities are known as soon as a jug is filled, tilted or a pour into it stops before the origin is empty, and
+      until then every level is a lower bound of the capacity. Jugs are interchangeable, so inferences that only differ
+      in the order of their jugs are the same one.
+*/
+type inference []inferredLevel
+
+type inferredLevel struct {
+	// capacity is 0 while it's unknown
+	capacity int
+	// min is the highest level a jug of unknown capacity has held
+	min   int
+	level int
+}
+
+func (s *service) InferCapacities(spec *InferSpec) (*InferResponse, *AppError) {
+	if err := validateInferSpec(spec); err != nil {
+		return nil, err
+	}
+
+	jugs := spec.Jugs
+	if jugs == 0 {
+		jugs = defaultInferJugs
+	}
+
+	// Every operation is tried on every jug of every inference, keeping the inferences it's consistent with
+	inferences := []inference{make(inference, jugs)}
+	total := 0
+	for i, operation := range spec.Operations {
+		next := map[string]inference{}
+		for _, in := range inferences {
+			for _, applied := range in.apply(operation) {
+				next[applied.key()] = applied
+			}
+		}
+
+		if len(next) == 0 {
+			return nil, &AppError{
+				Error: fmt.Errorf("log is contradictory at operation %d, as no jug can %s %d after the previous ones",
+					i+1, operation.OperationType, operation.WaterAmount),
+				Message: "invalid parameters",
+				Code:    http.StatusBadRequest,
+			}
+		}
+		if total += len(next); total > maxInferences {
+			return nil, &AppError{
+				Error: fmt.Errorf("there are more than %d ways to assign the operations to %d jugs, try fewer jugs",
+					maxInferences, jugs),
+				Message: "invalid parameters",
+				Code:    http.StatusBadRequest,
+			}
+		}
+
+		inferences = inferences[:0]
+		for _, in := range next {
+			inferences = append(inferences, in)
+		}
+	}
+
+	response := &InferResponse{}
+	for _, in := range inferences {
+		response.Candidates = append(response.Candidates, in.jugs())
+	}
+	// Candidates with smaller jugs go first
+	sort.Slice(response.Candidates, func(i, j int) bool {
+		a, b := response.Candidates[i], response.Candidates[j]
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k].less(b[k])
+			}
+		}
+		return len(a) < len(b)
+	})
+	return response, nil
+}
+
+// apply returns the inferences reached by performing the operation, which must be valid, on every possible jug
+func (in inference) apply(operation Operation) []inference {
+	amount := operation.WaterAmount
+
+	var inferences []inference
+	for i := range in {
+		if operation.OperationType != operationTypePour {
+			if next := in.copy(); next.perform(operation.OperationType, i, amount) {
+				inferences = append(inferences, next)
+			}
+			continue
+		}
+
+		for j := range in {
+			if i == j {
+				continue
+			}
+			if next := in.copy(); next.pour(i, j, amount) {
+				inferences = append(inferences, next)
+			}
+		}
+	}
+	return inferences
+}
+
+// perform performs the fill, empty or tilt of the jug, telling whether it's consistent with the previous operations
+func (in inference) perform(operationType OperationType, jug, amount int) bool {
+	switch operationType {
+	case operationTypeFill:
+		return in.fill(jug, in[jug].level+amount)
+	case operationTypeEmpty:
+		if in[jug].level != amount {
+			return false
+		}
+		in[jug].level = 0
+		return true
+	default:
+		// Tilting spills the water above half the capacity
+		half := in[jug].level - amount
+		if half <= 0 || !in.fill(jug, 2*half) {
+			return false
+		}
+		in[jug].level = half
+		return true
+	}
+}
+
+// pour pours amount from origin into destination, telling whether it's consistent with the previous operations
+func (in inference) pour(origin, destination, amount int) bool {
+	if in[origin].level < amount {
+		return false
+	}
+
+	in[origin].level -= amount
+	in[destination].level += amount
+	// Pours stop when the origin is empty or the destination is full, so it's full unless the origin is empty
+	if in[origin].level > 0 {
+		return in.fill(destination, in[destination].level)
+	}
+	return in.hold(destination)
+}
+
+// fill checks that capacity is the capacity of jug, which must hold every level it has held, and leaves it full
+func (in inference) fill(jug, capacity int) bool {
+	if known := in[jug].capacity; known != 0 && known != capacity || capacity < in[jug].min {
+		return false
+	}
+
+	in[jug] = inferredLevel{capacity: capacity, level: capacity}
+	return true
+}
+
+// hold checks that jug can hold its level
+func (in inference) hold(jug int) bool {
+	if in[jug].capacity != 0 {
+		return in[jug].level <= in[jug].capacity
+	}
+
+	in[jug].min = max(in[jug].min, in[jug].level)
+	return true
+}
+
+func (in inference) copy() inference {
+	return append(inference(nil), in...)
+}
+
+// key identifies the inference regardless of the order of its jugs, which are sorted along the way
+func (in inference) key() string {
+	sort.Slice(in, func(i, j int) bool {
+		a, b := in[i], in[j]
+		if a.capacity != b.capacity {
+			return a.capacity < b.capacity
+		}
+		if a.min != b.min {
+			return a.min < b.min
+		}
+		return a.level < b.level
+	})
+
+	fields := make([]string, len(in))
+	for i, jug := range in {
+		fields[i] = strconv.Itoa(jug.capacity) + "/" + strconv.Itoa(jug.min) + "/" + strconv.Itoa(jug.level)
+	}
+	return strings.Join(fields, ",")
+}
+
+// jugs returns the jugs used by the log, by capacity or else by least capacity
+func (in inference) jugs() []InferredJug {
+	var jugs []InferredJug
+	for _, jug := range in {
+		// Levels are always positive right after an operation on a jug, so unused jugs never held any water
+		if jug.capacity == 0 && jug.min == 0 {
+			continue
+		}
+		jugs = append(jugs, InferredJug{Capacity: jug.capacity, MinCapacity: jug.min, Level: jug.level})
+	}
+
+	sort.Slice(jugs, func(i, j int) bool {
+		return jugs[i].less(jugs[j])
+	})
+	return jugs
+}
+
+// less tells whether jug is smaller than other, by capacity or else by least capacity, then by level
+func (jug InferredJug) less(other InferredJug) bool {
+	if size, otherSize := max(jug.Capacity, jug.MinCapacity), max(other.Capacity, other.MinCapacity); size != otherSize {
+		return size < otherSize
+	}
+	if jug.Capacity != other.Capacity {
+		return jug.Capacity > other.Capacity
+	}
+	return jug.Level < other.Level
+}
+
+func validateInferSpec(spec *InferSpec) *AppError {
+	if len(spec.Operations) == 0 {
+		return &AppError{
+			Error:   errors.New("at least one operation is required"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if spec.Jugs < 0 || spec.Jugs > maxInferJugs {
+		return &AppError{
+			Error:   fmt.Errorf("jugs must be between 1 and %d, or 0 for the default of %d", maxInferJugs, defaultInferJugs),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	for i, operation := range spec.Operations {
+		if err := validateLoggedOperation(operation); err != nil {
+			return &AppError{
+				Error:   fmt.Errorf("operation %d is not valid, as %v", i+1, err),
+				Message: "invalid parameters",
+				Code:    http.StatusBadRequest,
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateLoggedOperation checks that the operation only tells the water it moves
+func validateLoggedOperation(operation Operation) error {
+	if operation.Jug != nil || operation.JugOrigin != nil || operation.JugDestination != nil {
+		return errors.New("it names its jugs, but only the amounts moved are observed")
+	}
+	if operation.Mark != 0 {
+		return errors.New("it stops at a mark, which doesn't tell the capacity of the jug")
+	}
+	if operation.WaterAmount <= 0 {
+		return errors.New("it doesn't move any water")
+	}
+
+	return validateOperationType(operation.OperationType)
+}
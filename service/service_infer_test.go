@@ -0,0 +1,184 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_InferCapacities(t *testing.T) {
+	// logged returns an operation as it's observed, without its jugs
+	logged := func(operationType OperationType, amount int) Operation {
+		return Operation{OperationType: operationType, WaterAmount: amount}
+	}
+
+	type want struct {
+		output    *InferResponse
+		outputErr *AppError
+	}
+	tests := []struct {
+		name string
+		spec *InferSpec
+		want want
+	}{
+		{
+			name: "operations are missing",
+			spec: &InferSpec{},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("at least one operation is required"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "too many jugs",
+			spec: &InferSpec{
+				Operations: []Operation{logged(operationTypeFill, 3)},
+				Jugs:       5,
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("jugs must be between 1 and 4, or 0 for the default of 2"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "operation is unknown",
+			spec: &InferSpec{
+				Operations: []Operation{logged("spill", 2)},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("operation 1 is not valid, as unknown operation spill"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "operation names its jugs",
+			spec: &InferSpec{
+				Operations: []Operation{
+					logged(operationTypeFill, 3),
+					{OperationType: operationTypePour, JugOrigin: aws.String(xJugTag), WaterAmount: 3},
+				},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("operation 2 is not valid, as it names its jugs, but only the amounts moved are observed"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "empty moves more water than any jug holds",
+			spec: &InferSpec{
+				Operations: []Operation{
+					logged(operationTypeFill, 5),
+					logged(operationTypePour, 3),
+					logged(operationTypeEmpty, 5),
+				},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("log is contradictory at operation 3, as no jug can empty 5 after the previous ones"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "success with every capacity",
+			spec: &InferSpec{
+				Operations: []Operation{
+					logged(operationTypeFill, 5),
+					logged(operationTypePour, 3),
+					logged(operationTypeEmpty, 3),
+					logged(operationTypePour, 2),
+					logged(operationTypeFill, 5),
+					logged(operationTypePour, 1),
+				},
+			},
+			want: want{
+				output: &InferResponse{
+					Candidates: [][]InferredJug{
+						{{Capacity: 3, Level: 3}, {Capacity: 5, Level: 4}},
+					},
+				},
+			},
+		},
+		{
+			name: "success with every jug the log can be performed with",
+			spec: &InferSpec{
+				Operations: []Operation{
+					logged(operationTypeFill, 3),
+					logged(operationTypePour, 3),
+					logged(operationTypeFill, 3),
+					logged(operationTypePour, 3),
+				},
+			},
+			want: want{
+				output: &InferResponse{
+					Candidates: [][]InferredJug{
+						{{Capacity: 3, Level: 0}, {MinCapacity: 6, Level: 6}},
+						{{Capacity: 3, Level: 3}, {Capacity: 6, Level: 3}},
+					},
+				},
+			},
+		},
+		{
+			name: "success with the capacity told by a tilt",
+			spec: &InferSpec{
+				Operations: []Operation{
+					logged(operationTypeFill, 3),
+					logged(operationTypePour, 3),
+					logged(operationTypeFill, 3),
+					logged(operationTypePour, 3),
+					logged(operationTypeTilt, 2),
+				},
+			},
+			want: want{
+				output: &InferResponse{
+					Candidates: [][]InferredJug{
+						{{Capacity: 3, Level: 0}, {Capacity: 8, Level: 4}},
+					},
+				},
+			},
+		},
+		{
+			name: "success with a jug that is never used",
+			spec: &InferSpec{
+				Operations: []Operation{
+					logged(operationTypeFill, 5),
+					logged(operationTypePour, 3),
+				},
+				Jugs: 3,
+			},
+			want: want{
+				output: &InferResponse{
+					Candidates: [][]InferredJug{
+						{{Capacity: 3, Level: 3}, {Capacity: 5, Level: 2}},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &service{}
+			output, outputErr := svc.InferCapacities(tt.spec)
+
+			a := assert.New(t)
+			a.Equal(tt.want.output, output)
+			a.Equal(tt.want.outputErr, outputErr)
+		})
+	}
+}
@@ -0,0 +1,438 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// RiddleSpec describes a Water Jug Riddle with an arbitrary number of jugs
+type RiddleSpec struct {
+	// Capacities contains the capacity of every jug
+	Capacities []int `json:"capacities,omitempty"`
+	// Names contains the tag used to refer to every jug. Jugs are tagged "1", "2", ... when it's empty
+	Names []string `json:"names,omitempty"`
+	// Initial contains the amount of water in every jug before the first operation. Jugs start empty when it's empty
+	Initial []int `json:"initial,omitempty"`
+	// Z is the amount of water to measure
+	Z int `json:"z,omitempty"`
+	// Total measures z as the water held by every jug together, instead of in a single jug
+	Total bool `json:"total,omitempty"`
+	// Goal contains the exact state of jugs to reach. When present, z is only required to be measured in its jug, or
+	// can be missing if its levels are enough
+	Goal *RiddleGoal `json:"goal,omitempty"`
+	// Supply is the most water that can be drawn from the tap by every fill operation. The tap is unlimited when it's
+	// missing
+	Supply *int `json:"supply,omitempty"`
+	// Tilt contains the tags of the jugs that can be tilted to leave exactly half their capacity, which must be even
+	Tilt []string `json:"tilt,omitempty"`
+	// Marks contains the graduation marks of some jugs, by tag. Fills and pours can stop exactly at a mark
+	Marks map[string][]int `json:"marks,omitempty"`
+	// Restrictions contains the operations that can't be used by the plan
+	Restrictions *Restrictions `json:"restrictions,omitempty"`
+	// Topology contains the connections water can flow through. Every operation is possible when it's missing
+	Topology *Topology `json:"topology,omitempty"`
+	// Costs contains the cost of every operation type. When present, the cheapest plan is returned instead of the
+	// shortest one
+	Costs map[OperationType]OperationCost `json:"costs,omitempty"`
+}
+
+func (s *service) SolveRiddle(spec *RiddleSpec) (*RiddleResponse, *AppError) {
+	if err := validateRiddleSpec(spec); err != nil {
+		return nil, err
+	}
+
+	tags := spec.tags()
+
+	var node *searchNode
+	var searchErr error
+	if spec.Costs != nil {
+		node, searchErr = cheapestSearch(spec.space(), spec.initial(), spec.goal(), operationCosts(spec.Costs))
+	} else {
+		node, searchErr = search(spec.space(), spec.initial(), spec.goal())
+	}
+	if searchErr != nil {
+		return nil, &AppError{
+			Error:   searchErr,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+	if node == nil {
+		return nil, spec.unsolvable()
+	}
+
+	response := &RiddleResponse{
+		Operations: node.operations(),
+		Jug:        spec.jug(node.state, tags),
+		TotalSteps: node.depth,
+		Levels:     node.state.levels(tags),
+		Optimal:    true,
+	}
+	if spec.Costs != nil {
+		response.TotalCost = aws.Float64(node.cost)
+	}
+	return response, nil
+}
+
+// goal returns the check for the states where z has been measured, matching the goal if there is one
+func (spec *RiddleSpec) goal() func(jugState) bool {
+	measured := spec.measured()
+	if spec.Goal == nil {
+		return measured
+	}
+
+	indexes := map[string]int{}
+	for i, tag := range spec.tags() {
+		indexes[tag] = i
+	}
+	accepts := spec.Goal.accepts(indexes, spec.Z)
+	return func(state jugState) bool {
+		return accepts(state) && measured(state)
+	}
+}
+
+// measured returns the check for the states where z has been measured, which is any state when there is no z
+func (spec *RiddleSpec) measured() func(jugState) bool {
+	switch {
+	case spec.Z == 0:
+		return func(jugState) bool {
+			return true
+		}
+	case spec.Total:
+		return func(state jugState) bool {
+			return state.total() == spec.Z
+		}
+	default:
+		return func(state jugState) bool {
+			return jugWith(state, spec.Z) >= 0
+		}
+	}
+}
+
+// jug returns the tag of the jug holding z, or the whole state when there is a goal
+func (spec *RiddleSpec) jug(state jugState, tags []string) string {
+	switch {
+	case spec.Goal != nil:
+		return state.describe(tags)
+	case spec.Total:
+		return ""
+	default:
+		return tags[jugWith(state, spec.Z)]
+	}
+}
+
+/*
+ unsolvable returns the error for riddles without any plan reaching the goal. The supply, topology or restrictions
+      are only to blame when the riddle has a plan without them, and otherwise the goal can't be reached at all.
+*/
+func (spec *RiddleSpec) unsolvable() *AppError {
+	if spec.Supply != nil {
+		if needed, ok := spec.neededSupply(); ok {
+			return &AppError{
+				Error: fmt.Errorf("there is no solution drawing at most %d units of water, as at least %d are needed",
+					*spec.Supply, needed),
+				Message: "invalid parameters",
+				Code:    http.StatusBadRequest,
+			}
+		}
+	}
+	if spec.Topology != nil && spec.solvableWithout(func(relaxed *RiddleSpec) { relaxed.Topology = nil }) {
+		return &AppError{
+			Error:   fmt.Errorf("there is no solution with jugs with %v along their connections", spec.Capacities),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+	if spec.Restrictions != nil && spec.solvableWithout(func(relaxed *RiddleSpec) { relaxed.Restrictions = nil }) {
+		return &AppError{
+			Error:   fmt.Errorf("there is no solution with jugs with %v without the restricted operations", spec.Capacities),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return spec.unmeasurable()
+}
+
+// solvableWithout tells whether the riddle has a plan once relax removes one of its constraints
+func (spec *RiddleSpec) solvableWithout(relax func(*RiddleSpec)) bool {
+	relaxed := *spec
+	relax(&relaxed)
+	node, err := search(relaxed.space(), relaxed.initial(), relaxed.goal())
+	return err == nil && node != nil
+}
+
+// unmeasurable returns the error for riddles whose goal can't be reached even by jugs without any restriction
+func (spec *RiddleSpec) unmeasurable() *AppError {
+	if spec.Goal != nil {
+		return &AppError{
+			Error:   fmt.Errorf("there is no solution to reach the goal with jugs with %v", spec.Capacities),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return &AppError{
+		Error:   fmt.Errorf("there is no solution to measure %d with jugs with %v", spec.Z, spec.Capacities),
+		Message: "invalid parameters",
+		Code:    http.StatusBadRequest,
+	}
+}
+
+// initial returns the state of jugs before the first operation
+func (spec *RiddleSpec) initial() jugState {
+	initial := make(jugState, len(spec.Capacities))
+	copy(initial, spec.Initial)
+	return initial
+}
+
+// space returns the jugs of the riddle and the rules every operation follows
+func (spec *RiddleSpec) space() *searchSpace {
+	space := &searchSpace{
+		capacities: spec.Capacities,
+		tags:       spec.tags(),
+		supply:     spec.Supply,
+		marks:      spec.marks(spec.tags()),
+	}
+	var restricted, connected func(Operation) bool
+	if spec.Restrictions != nil {
+		restricted = spec.Restrictions.allows()
+	}
+	if spec.Topology != nil {
+		connected = spec.Topology.allows()
+	}
+	space.allows = allOf(restricted, connected)
+	if len(spec.Tilt) > 0 {
+		space.tiltable = make([]bool, len(spec.Capacities))
+		for _, tag := range spec.Tilt {
+			space.tiltable[indexOf(space.tags, tag)] = true
+		}
+	}
+	return space
+}
+
+// halves returns half the capacity of every jug that can be tilted
+func (spec *RiddleSpec) halves() []int {
+	halves := make([]int, len(spec.Tilt))
+	for i, tag := range spec.Tilt {
+		halves[i] = spec.Capacities[indexOf(spec.tags(), tag)] / 2
+	}
+	return halves
+}
+
+func (spec *RiddleSpec) tags() []string {
+	return jugTags(len(spec.Capacities), spec.Names)
+}
+
+// jugTags returns the names of the jugs, or "1", "2", ... when there are none
+func jugTags(jugs int, names []string) []string {
+	if len(names) > 0 {
+		return names
+	}
+
+	tags := make([]string, jugs)
+	for i := range tags {
+		tags[i] = strconv.Itoa(i + 1)
+	}
+	return tags
+}
+
+func validateRiddleSpec(spec *RiddleSpec) *AppError {
+	if len(spec.Capacities) == 0 {
+		return &AppError{
+			Error:   errors.New("at least one jug is required"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if len(spec.Names) > 0 {
+		if err := validateJugNames(spec.Names, len(spec.Capacities)); err != nil {
+			return &AppError{
+				Error:   err,
+				Message: "invalid parameters",
+				Code:    http.StatusBadRequest,
+			}
+		}
+	}
+
+	if len(spec.Initial) > 0 {
+		if err := validateInitialLevels(spec.Initial, spec.Capacities, spec.tags()); err != nil {
+			return &AppError{
+				Error:   err,
+				Message: "invalid parameters",
+				Code:    http.StatusBadRequest,
+			}
+		}
+	}
+
+	if spec.Goal != nil {
+		if err := validateRiddleGoal(spec, spec.tags()); err != nil {
+			return &AppError{
+				Error:   err,
+				Message: "invalid parameters",
+				Code:    http.StatusBadRequest,
+			}
+		}
+	}
+
+	if err := validateTilt(spec.Tilt, spec.Capacities, spec.tags()); err != nil {
+		return &AppError{
+			Error:   err,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if err := validateMarks(spec.Marks, spec.Capacities, spec.tags()); err != nil {
+		return &AppError{
+			Error:   err,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if spec.Restrictions != nil {
+		if err := validateRestrictions(spec.Restrictions, spec.tags()); err != nil {
+			return &AppError{
+				Error:   err,
+				Message: "invalid parameters",
+				Code:    http.StatusBadRequest,
+			}
+		}
+	}
+
+	if spec.Topology != nil {
+		if err := validateTopology(spec.Topology, spec.tags()); err != nil {
+			return &AppError{
+				Error:   err,
+				Message: "invalid parameters",
+				Code:    http.StatusBadRequest,
+			}
+		}
+	}
+
+	if spec.Supply != nil && *spec.Supply < 0 {
+		return &AppError{
+			Error:   errors.New("supply can't be negative"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if err := validateOperationCosts(spec.Costs); err != nil {
+		return &AppError{
+			Error:   err,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if spec.Total && spec.Z > sumOf(spec.Capacities...) {
+		return &AppError{
+			Error:   fmt.Errorf("can't measure %d if it's bigger than the total of jugs for %v", spec.Z, spec.Capacities),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if !spec.Total && spec.Z > maxOf(spec.Capacities...) {
+		return &AppError{
+			Error:   fmt.Errorf("can't measure %d if it's bigger than jugs for %v", spec.Z, spec.Capacities),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	// If the divisor of every level does not divide z, then solution is not possible. It's only a precheck though, as
+	// restrictions, connections or a limited supply can leave the riddle without solution anyway, which the search
+	// finds out
+	divisor := spec.divisor()
+	if spec.Z%divisor != 0 {
+		return spec.unmeasurable()
+	}
+	if spec.Goal != nil {
+		for _, level := range spec.Goal.Levels {
+			if level%divisor != 0 {
+				return spec.unmeasurable()
+			}
+		}
+	}
+
+	return nil
+}
+
+// divisor returns the gcd of every jug, initial level, half of the tiltable jugs and mark, which divides every level
+// jugs can hold, as they are always a combination of them
+func (spec *RiddleSpec) divisor() int {
+	// Initial may have room to spare, so it's copied before appending the rest
+	levels := append([]int(nil), spec.Initial...)
+	levels = append(append(append(levels, spec.Capacities...), spec.halves()...), spec.allMarks()...)
+	return gcdOf(levels...)
+}
+
+func validateJugNames(names []string, jugs int) error {
+	if len(names) != jugs {
+		return fmt.Errorf("expected %d names but got %d", jugs, len(names))
+	}
+
+	seen := map[string]bool{}
+	for _, name := range names {
+		if name == "" {
+			return errors.New("jug names can't be empty")
+		}
+		if seen[name] {
+			return fmt.Errorf("jug name %s is repeated", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+func validateTilt(tilt []string, capacities []int, tags []string) error {
+	for _, tag := range tilt {
+		i := indexOf(tags, tag)
+		if i < 0 {
+			return fmt.Errorf("unknown jug %s", tag)
+		}
+		if capacities[i]%2 != 0 {
+			return fmt.Errorf("jug %s can't be tilted to half, as its capacity %d is odd", tag, capacities[i])
+		}
+	}
+	return nil
+}
+
+func validateInitialLevels(levels, capacities []int, tags []string) error {
+	if len(levels) != len(capacities) {
+		return fmt.Errorf("expected %d initial levels but got %d", len(capacities), len(levels))
+	}
+
+	for i, level := range levels {
+		if level < 0 || level > capacities[i] {
+			return fmt.Errorf("initial level of jug %s must be between 0 and %d", tags[i], capacities[i])
+		}
+	}
+	return nil
+}
+
+// indexOf returns the index of the jug tagged tag, or -1 if there is none
+func indexOf(tags []string, tag string) int {
+	for i, t := range tags {
+		if t == tag {
+			return i
+		}
+	}
+	return -1
+}
+
+// jugWith returns the index of the first jug holding z, or -1 if there is none
+func jugWith(state jugState, z int) int {
+	for i, level := range state {
+		if level == z {
+			return i
+		}
+	}
+	return -1
+}
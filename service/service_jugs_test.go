@@ -0,0 +1,518 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_SolveRiddle(t *testing.T) {
+	type want struct {
+		output    *RiddleResponse
+		outputErr *AppError
+	}
+	tests := []struct {
+		name string
+		spec *RiddleSpec
+		want want
+	}{
+		{
+			name: "names don't match the jugs",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5, 7},
+				Names:      []string{"a", "b"},
+				Z:          1,
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("expected 3 names but got 2"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "names are repeated",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Names:      []string{"a", "a"},
+				Z:          1,
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("jug name a is repeated"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "z is bigger than every jug",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5, 7},
+				Z:          8,
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("can't measure %d if it's bigger than jugs for %v", 8, []int{3, 5, 7}),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "gcd of every jug doesn't divide z",
+			spec: &RiddleSpec{
+				Capacities: []int{4, 6, 8},
+				Z:          3,
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("there is no solution to measure %d with jugs with %v", 3, []int{4, 6, 8}),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "too many states to search",
+			spec: &RiddleSpec{
+				Capacities: []int{800, 801, 802},
+				Z:          400,
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("there are more than %d states to search, try smaller or fewer jugs", maxSearchStates),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "success with capacities = [3, 5, 7] and z = 1",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5, 7},
+				Z:          1,
+			},
+			want: want{
+				output: &RiddleResponse{
+					Operations: []Operation{
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String("1"),
+							WaterAmount:   3,
+							Step:          1,
+							Description:   "filling jug 1 with 3 capacity",
+						},
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String("2"),
+							WaterAmount:   5,
+							Step:          2,
+							Description:   "filling jug 2 with 5 capacity",
+						},
+						{
+							OperationType:  operationTypePour,
+							JugOrigin:      aws.String("1"),
+							JugDestination: aws.String("3"),
+							WaterAmount:    3,
+							Step:           3,
+							Description:    "pouring water from jug 1 to 3",
+						},
+						{
+							OperationType:  operationTypePour,
+							JugOrigin:      aws.String("2"),
+							JugDestination: aws.String("3"),
+							WaterAmount:    4,
+							Step:           4,
+							Description:    "pouring water from jug 2 to 3",
+						},
+					},
+					Jug:        "2",
+					TotalSteps: 4,
+					Levels:     map[string]int{"1": 0, "2": 1, "3": 7},
+					Optimal:    true,
+				},
+			},
+		},
+		{
+			name: "success with named jugs",
+			spec: &RiddleSpec{
+				Capacities: []int{2, 3},
+				Names:      []string{"small", "big"},
+				Z:          1,
+			},
+			want: want{
+				output: &RiddleResponse{
+					Operations: []Operation{
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String("big"),
+							WaterAmount:   3,
+							Step:          1,
+							Description:   "filling jug big with 3 capacity",
+						},
+						{
+							OperationType:  operationTypePour,
+							JugOrigin:      aws.String("big"),
+							JugDestination: aws.String("small"),
+							WaterAmount:    2,
+							Step:           2,
+							Description:    "pouring water from jug big to small",
+						},
+					},
+					Jug:        "big",
+					TotalSteps: 2,
+					Levels:     map[string]int{"small": 2, "big": 1},
+					Optimal:    true,
+				},
+			},
+		},
+		{
+			name: "z is bigger than the total of jugs",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Z:          9,
+				Total:      true,
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("can't measure %d if it's bigger than the total of jugs for %v", 9, []int{3, 5}),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "success measuring the total of jugs with capacities = [3, 5] and z = 8",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Names:      []string{xJugTag, yJugTag},
+				Z:          8,
+				Total:      true,
+			},
+			want: want{
+				output: &RiddleResponse{
+					Operations: []Operation{
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String(xJugTag),
+							WaterAmount:   3,
+							Step:          1,
+							Description:   fmt.Sprintf("filling jug %s with 3 capacity", xJugTag),
+						},
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String(yJugTag),
+							WaterAmount:   5,
+							Step:          2,
+							Description:   fmt.Sprintf("filling jug %s with 5 capacity", yJugTag),
+						},
+					},
+					TotalSteps: 2,
+					Levels:     map[string]int{xJugTag: 3, yJugTag: 5},
+					Optimal:    true,
+				},
+			},
+		},
+		{
+			name: "success measuring the total of jugs with capacities = [3, 5] and z = 7",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Names:      []string{xJugTag, yJugTag},
+				Z:          7,
+				Total:      true,
+			},
+			want: want{
+				output: &RiddleResponse{
+					Operations: []Operation{
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String(yJugTag),
+							WaterAmount:   5,
+							Step:          1,
+							Description:   fmt.Sprintf("filling jug %s with 5 capacity", yJugTag),
+						},
+						{
+							OperationType:  operationTypePour,
+							JugOrigin:      aws.String(yJugTag),
+							JugDestination: aws.String(xJugTag),
+							WaterAmount:    3,
+							Step:           2,
+							Description:    fmt.Sprintf("pouring water from jug %s to %s", yJugTag, xJugTag),
+						},
+						{
+							OperationType: operationTypeEmpty,
+							Jug:           aws.String(xJugTag),
+							WaterAmount:   3,
+							Step:          3,
+							Description:   fmt.Sprintf("emptying jug %s with 3 capacity", xJugTag),
+						},
+						{
+							OperationType:  operationTypePour,
+							JugOrigin:      aws.String(yJugTag),
+							JugDestination: aws.String(xJugTag),
+							WaterAmount:    2,
+							Step:           4,
+							Description:    fmt.Sprintf("pouring water from jug %s to %s", yJugTag, xJugTag),
+						},
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String(yJugTag),
+							WaterAmount:   5,
+							Step:          5,
+							Description:   fmt.Sprintf("filling jug %s with 5 capacity", yJugTag),
+						},
+					},
+					TotalSteps: 5,
+					Levels:     map[string]int{xJugTag: 2, yJugTag: 5},
+					Optimal:    true,
+				},
+			},
+		},
+		{
+			name: "costs are negative",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Z:          4,
+				Costs: map[OperationType]OperationCost{
+					operationTypeEmpty: {Base: -1},
+				},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("costs can't be negative"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "costs of unknown operation",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Z:          4,
+				Costs: map[OperationType]OperationCost{
+					"spill": {Base: 1},
+				},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("unknown operation spill"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "success with the cheapest plan when filling is priced by volume",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Names:      []string{xJugTag, yJugTag},
+				Z:          4,
+				Costs: map[OperationType]OperationCost{
+					operationTypeFill:  {PerUnit: 1},
+					operationTypeEmpty: {},
+					operationTypePour:  {Base: 0.5},
+				},
+			},
+			want: want{
+				output: &RiddleResponse{
+					Operations: []Operation{
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String(xJugTag),
+							WaterAmount:   3,
+							Step:          1,
+							Description:   fmt.Sprintf("filling jug %s with 3 capacity", xJugTag),
+							Cost:          3,
+						},
+						{
+							OperationType:  operationTypePour,
+							JugOrigin:      aws.String(xJugTag),
+							JugDestination: aws.String(yJugTag),
+							WaterAmount:    3,
+							Step:           2,
+							Description:    fmt.Sprintf("pouring water from jug %s to %s", xJugTag, yJugTag),
+							Cost:           0.5,
+						},
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String(xJugTag),
+							WaterAmount:   3,
+							Step:          3,
+							Description:   fmt.Sprintf("filling jug %s with 3 capacity", xJugTag),
+							Cost:          3,
+						},
+						{
+							OperationType:  operationTypePour,
+							JugOrigin:      aws.String(xJugTag),
+							JugDestination: aws.String(yJugTag),
+							WaterAmount:    2,
+							Step:           4,
+							Description:    fmt.Sprintf("pouring water from jug %s to %s", xJugTag, yJugTag),
+							Cost:           0.5,
+						},
+						{
+							OperationType: operationTypeEmpty,
+							Jug:           aws.String(yJugTag),
+							WaterAmount:   5,
+							Step:          5,
+							Description:   fmt.Sprintf("emptying jug %s with 5 capacity", yJugTag),
+						},
+						{
+							OperationType:  operationTypePour,
+							JugOrigin:      aws.String(xJugTag),
+							JugDestination: aws.String(yJugTag),
+							WaterAmount:    1,
+							Step:           6,
+							Description:    fmt.Sprintf("pouring water from jug %s to %s", xJugTag, yJugTag),
+							Cost:           0.5,
+						},
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String(xJugTag),
+							WaterAmount:   3,
+							Step:          7,
+							Description:   fmt.Sprintf("filling jug %s with 3 capacity", xJugTag),
+							Cost:          3,
+						},
+						{
+							OperationType:  operationTypePour,
+							JugOrigin:      aws.String(xJugTag),
+							JugDestination: aws.String(yJugTag),
+							WaterAmount:    3,
+							Step:           8,
+							Description:    fmt.Sprintf("pouring water from jug %s to %s", xJugTag, yJugTag),
+							Cost:           0.5,
+						},
+					},
+					Jug:        yJugTag,
+					TotalSteps: 8,
+					Levels:     map[string]int{xJugTag: 0, yJugTag: 4},
+					TotalCost:  aws.Float64(11),
+					Optimal:    true,
+				},
+			},
+		},
+		{
+			name: "initial levels don't match the jugs",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Initial:    []int{1},
+				Z:          4,
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("expected 2 initial levels but got 1"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "initial level is bigger than its jug",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Names:      []string{xJugTag, yJugTag},
+				Initial:    []int{4, 0},
+				Z:          4,
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("initial level of jug %s must be between 0 and 3", xJugTag),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "success with initial levels making z measurable",
+			spec: &RiddleSpec{
+				Capacities: []int{4, 6},
+				Names:      []string{xJugTag, yJugTag},
+				Initial:    []int{3, 0},
+				Z:          1,
+			},
+			want: want{
+				output: &RiddleResponse{
+					Operations: []Operation{
+						{
+							OperationType:  operationTypePour,
+							JugOrigin:      aws.String(xJugTag),
+							JugDestination: aws.String(yJugTag),
+							WaterAmount:    3,
+							Step:           1,
+							Description:    fmt.Sprintf("pouring water from jug %s to %s", xJugTag, yJugTag),
+						},
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String(xJugTag),
+							WaterAmount:   4,
+							Step:          2,
+							Description:   fmt.Sprintf("filling jug %s with 4 capacity", xJugTag),
+						},
+						{
+							OperationType:  operationTypePour,
+							JugOrigin:      aws.String(xJugTag),
+							JugDestination: aws.String(yJugTag),
+							WaterAmount:    3,
+							Step:           3,
+							Description:    fmt.Sprintf("pouring water from jug %s to %s", xJugTag, yJugTag),
+						},
+					},
+					Jug:        xJugTag,
+					TotalSteps: 3,
+					Levels:     map[string]int{xJugTag: 1, yJugTag: 6},
+					Optimal:    true,
+				},
+			},
+		},
+		{
+			name: "success with z already measured by initial levels",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Names:      []string{xJugTag, yJugTag},
+				Initial:    []int{0, 4},
+				Z:          4,
+			},
+			want: want{
+				output: &RiddleResponse{
+					Operations: []Operation{},
+					Jug:        yJugTag,
+					Levels:     map[string]int{xJugTag: 0, yJugTag: 4},
+					Optimal:    true,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &service{}
+			output, outputErr := svc.SolveRiddle(tt.spec)
+
+			a := assert.New(t)
+			a.Equal(tt.want.output, output)
+			a.Equal(tt.want.outputErr, outputErr)
+		})
+	}
+}
+
+func TestRiddleSpec_Divisor(t *testing.T) {
+	// Initial levels with room to spare, like the ones decoded from JSON, are left as they are
+	initial := make([]int, 2, 8)
+	initial[1] = 4
+	spec := &RiddleSpec{
+		Capacities: []int{6, 10},
+		Names:      []string{xJugTag, yJugTag},
+		Initial:    initial,
+	}
+
+	a := assert.New(t)
+	a.Equal(2, spec.divisor())
+	a.Equal([]int{0, 4, 0, 0, 0, 0, 0, 0}, initial[:cap(initial)])
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// markedPour is a pour that stops when jug reaches mark
+type markedPour struct {
+	amount int
+	jug    int
+	mark   int
+}
+
+func (sp *searchSpace) marksOf(jug int) []int {
+	if sp.marks == nil {
+		return nil
+	}
+	return sp.marks[jug]
+}
+
+/*
+ markedPours returns the pours from jug i into jug j that stop as soon as the destination rises to one of its marks or
+      the origin drops to one of its marks. Pours moving the same amount as poured, or as another marked pour, are left
+      out, as they reach the same state.
+*/
+func (sp *searchSpace) markedPours(state jugState, i, j, poured int) []markedPour {
+	var pours []markedPour
+	seen := map[int]bool{poured: true}
+
+	add := func(amount, jug, mark int) {
+		if amount <= 0 || amount > poured || seen[amount] {
+			return
+		}
+		seen[amount] = true
+		pours = append(pours, markedPour{amount: amount, jug: jug, mark: mark})
+	}
+
+	for _, mark := range sp.marksOf(j) {
+		add(mark-state[j], j, mark)
+	}
+	for _, mark := range sp.marksOf(i) {
+		add(state[i]-mark, i, mark)
+	}
+	return pours
+}
+
+// marks returns the graduation marks of every jug, sorted and without repetitions, or nil when there are none
+func (spec *RiddleSpec) marks(tags []string) [][]int {
+	if len(spec.Marks) == 0 {
+		return nil
+	}
+
+	marks := make([][]int, len(tags))
+	for i, tag := range tags {
+		seen := map[int]bool{}
+		for _, mark := range spec.Marks[tag] {
+			if !seen[mark] {
+				seen[mark] = true
+				marks[i] = append(marks[i], mark)
+			}
+		}
+		sort.Ints(marks[i])
+	}
+	return marks
+}
+
+// allMarks returns every graduation mark of every jug
+func (spec *RiddleSpec) allMarks() []int {
+	var marks []int
+	for _, jugMarks := range spec.Marks {
+		marks = append(marks, jugMarks...)
+	}
+	return marks
+}
+
+func validateMarks(marks map[string][]int, capacities []int, tags []string) error {
+	for tag, jugMarks := range marks {
+		i := indexOf(tags, tag)
+		if i < 0 {
+			return fmt.Errorf("unknown jug %s", tag)
+		}
+		for _, mark := range jugMarks {
+			if mark <= 0 || mark >= capacities[i] {
+				return fmt.Errorf("marks of jug %s must be between 1 and %d", tag, capacities[i]-1)
+			}
+		}
+	}
+	return nil
+}
+
+func markedFillOperation(jugTag string, mark, amount, step int) Operation {
+	return Operation{
+		OperationType: operationTypeFill,
+		Jug:           aws.String(jugTag),
+		WaterAmount:   amount,
+		Description:   fmt.Sprintf("filling jug %s up to mark %d", jugTag, mark),
+		Step:          step,
+		Mark:          mark,
+	}
+}
+
+func markedPourOperation(originTag, destinationTag, markTag string, mark, amount, step int) Operation {
+	return Operation{
+		OperationType:  operationTypePour,
+		JugOrigin:      aws.String(originTag),
+		JugDestination: aws.String(destinationTag),
+		WaterAmount:    amount,
+		Description: fmt.Sprintf("pouring water from jug %s to %s until jug %s reaches mark %d",
+			originTag, destinationTag, markTag, mark),
+		Step: step,
+		Mark: mark,
+	}
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_SolveRiddle_Marks(t *testing.T) {
+	type want struct {
+		output    *RiddleResponse
+		outputErr *AppError
+	}
+	tests := []struct {
+		name string
+		spec *RiddleSpec
+		want want
+	}{
+		{
+			name: "marked jug is unknown",
+			spec: &RiddleSpec{
+				Capacities: []int{4, 6},
+				Names:      []string{xJugTag, yJugTag},
+				Z:          2,
+				Marks:      map[string][]int{"w": {3}},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("unknown jug w"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "mark is at the capacity of the jug",
+			spec: &RiddleSpec{
+				Capacities: []int{4, 6},
+				Names:      []string{xJugTag, yJugTag},
+				Z:          2,
+				Marks:      map[string][]int{yJugTag: {3, 6}},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("marks of jug %s must be between 1 and 5", yJugTag),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "gcd of every jug and mark doesn't divide z",
+			spec: &RiddleSpec{
+				Capacities: []int{4, 8},
+				Names:      []string{xJugTag, yJugTag},
+				Z:          1,
+				Marks:      map[string][]int{xJugTag: {2}},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("there is no solution to measure %d with jugs with %v", 1, []int{4, 8}),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "success filling a jug up to a mark",
+			spec: &RiddleSpec{
+				Capacities: []int{4, 8},
+				Names:      []string{xJugTag, yJugTag},
+				Z:          2,
+				Marks:      map[string][]int{xJugTag: {2}},
+			},
+			want: want{
+				output: &RiddleResponse{
+					Operations: []Operation{
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String(xJugTag),
+							WaterAmount:   2,
+							Step:          1,
+							Description:   fmt.Sprintf("filling jug %s up to mark 2", xJugTag),
+							Mark:          2,
+						},
+					},
+					Jug:        xJugTag,
+					TotalSteps: 1,
+					Levels:     map[string]int{xJugTag: 2, yJugTag: 0},
+					Optimal:    true,
+				},
+			},
+		},
+		{
+			name: "success pouring until the destination reaches a mark",
+			spec: &RiddleSpec{
+				Capacities: []int{4, 6},
+				Names:      []string{xJugTag, yJugTag},
+				Z:          1,
+				Marks:      map[string][]int{yJugTag: {3}},
+			},
+			want: want{
+				output: &RiddleResponse{
+					Operations: []Operation{
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String(xJugTag),
+							WaterAmount:   4,
+							Step:          1,
+							Description:   fmt.Sprintf("filling jug %s with 4 capacity", xJugTag),
+						},
+						{
+							OperationType:  operationTypePour,
+							JugOrigin:      aws.String(xJugTag),
+							JugDestination: aws.String(yJugTag),
+							WaterAmount:    3,
+							Step:           2,
+							Description: fmt.Sprintf("pouring water from jug %s to %s until jug %s reaches mark 3",
+								xJugTag, yJugTag, yJugTag),
+							Mark: 3,
+						},
+					},
+					Jug:        xJugTag,
+					TotalSteps: 2,
+					Levels:     map[string]int{xJugTag: 1, yJugTag: 3},
+					Optimal:    true,
+				},
+			},
+		},
+		{
+			name: "success with a shorter plan pouring until the origin drops to a mark",
+			spec: &RiddleSpec{
+				Capacities: []int{7, 9},
+				Names:      []string{xJugTag, yJugTag},
+				Z:          4,
+				Marks:      map[string][]int{yJugTag: {5}},
+			},
+			want: want{
+				output: &RiddleResponse{
+					Operations: []Operation{
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String(yJugTag),
+							WaterAmount:   9,
+							Step:          1,
+							Description:   fmt.Sprintf("filling jug %s with 9 capacity", yJugTag),
+						},
+						{
+							OperationType:  operationTypePour,
+							JugOrigin:      aws.String(yJugTag),
+							JugDestination: aws.String(xJugTag),
+							WaterAmount:    4,
+							Step:           2,
+							Description: fmt.Sprintf("pouring water from jug %s to %s until jug %s reaches mark 5",
+								yJugTag, xJugTag, yJugTag),
+							Mark: 5,
+						},
+					},
+					Jug:        xJugTag,
+					TotalSteps: 2,
+					Levels:     map[string]int{xJugTag: 4, yJugTag: 5},
+					Optimal:    true,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &service{}
+			output, outputErr := svc.SolveRiddle(tt.spec)
+
+			a := assert.New(t)
+			a.Equal(tt.want.output, output)
+			a.Equal(tt.want.outputErr, outputErr)
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package service
 
+import "math/bits"
+
 func gcd(a, b int) int {
 	if b == 0 {
 		return a
@@ -14,4 +16,60 @@ func min(a, b int) int {
 	return b
 }
 
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// gcdOf returns the greatest common divisor of every value
+func gcdOf(values ...int) int {
+	result := 0
+	for _, v := range values {
+		result = gcd(v, result)
+	}
+	return result
+}
+
+func maxOf(values ...int) int {
+	result := 0
+	for _, v := range values {
+		result = max(result, v)
+	}
+	return result
+}
 
+func sumOf(values ...int) int {
+	result := 0
+	for _, v := range values {
+		result += v
+	}
+	return result
+}
+
+// extendedGcd returns the greatest common divisor of a and b, together with the coefficients of Bézout's identity
+// s and t so that a*s + b*t = g
+func extendedGcd(a, b int) (g, s, t int) {
+	if b == 0 {
+		return a, 1, 0
+	}
+	g, s1, t1 := extendedGcd(b, a%b)
+	return g, t1, s1 - (a/b)*t1
+}
+
+// modInverse returns the inverse of a modulo m, assuming that they are coprime
+func modInverse(a, m int) int {
+	_, s, _ := extendedGcd(a, m)
+	return ((s % m) + m) % m
+}
+
+func ceilDiv(a, b int) int {
+	return (a + b - 1) / b
+}
+
+// mulMod returns a*b modulo m without overflowing, assuming that a, b and m are not negative
+func mulMod(a, b, m int) int {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	return int(bits.Rem64(hi, lo, uint64(m)))
+}
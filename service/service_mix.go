@@ -0,0 +1,347 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+const (
+	// defaultMixSteps is the most steps a mixing plan can take when they are not given, as mixtures are endless
+	defaultMixSteps = 10
+	maxMixSteps     = 14
+	// maxMixtures is the most states the mixing search visits before giving up
+	maxMixtures = 50000
+	// maxConcentrationLength is the most characters a concentration can have, so it's never too big to parse
+	maxConcentrationLength = 32
+)
+
+// defaultLiquids are the liquids drawn from both taps when they are not given
+var defaultLiquids = []string{"water", "syrup"}
+
+// MixSpec describes a riddle where jugs are filled from two taps with different liquids, to mix them
+type MixSpec struct {
+	// Capacities contains the capacity of every jug
+	Capacities []int `json:"capacities,omitempty"`
+	// Names contains the tag used to refer to every jug. Jugs are tagged "1", "2", ... when it's empty
+	Names []string `json:"names,omitempty"`
+	// Liquids contains the names of the liquid of each tap. They are water and syrup when it's empty
+	Liquids []string `json:"liquids,omitempty"`
+	// Volume is the amount of mixture to measure in a single jug
+	Volume int `json:"volume,omitempty"`
+	// Concentration is the fraction of the volume that must be of the second liquid, such as "1/3" or "0.25"
+	Concentration string `json:"concentration,omitempty"`
+	// MaxSteps is the most steps the plan can take, as there is no end to the mixtures that can be made
+	MaxSteps int `json:"max_steps,omitempty"`
+}
+
+// mixNode is a state reached by the mixing search, where every jug holds some amount of the second liquid
+type mixNode struct {
+	state     jugState
+	second    []*big.Rat
+	parent    *mixNode
+	operation Operation
+	depth     int
+}
+
+func (n *mixNode) key() string {
+	var sb strings.Builder
+	sb.WriteString(n.state.key())
+	for _, amount := range n.second {
+		sb.WriteByte('|')
+		sb.WriteString(amount.RatString())
+	}
+	return sb.String()
+}
+
+func (n *mixNode) operations() []Operation {
+	operations := make([]Operation, n.depth)
+	for node := n; node.parent != nil; node = node.parent {
+		operations[node.depth-1] = node.operation
+	}
+	return operations
+}
+
+// mixtures returns the amount of every liquid in every jug, by tag, leaving out the liquids a jug doesn't hold
+func (n *mixNode) mixtures(tags, liquids []string) map[string]map[string]string {
+	mixtures := make(map[string]map[string]string, len(n.state))
+	for i, level := range n.state {
+		mixtures[tags[i]] = composition(liquids, big.NewRat(int64(level), 1), n.second[i])
+	}
+	return mixtures
+}
+
+func (s *service) MixRiddle(spec *MixSpec) (*RiddleResponse, *AppError) {
+	concentration, err := validateMixSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := jugTags(len(spec.Capacities), spec.Names)
+	liquids := spec.liquids()
+	maxSteps := spec.MaxSteps
+	if maxSteps == 0 {
+		maxSteps = defaultMixSteps
+	}
+
+	second := new(big.Rat).Mul(concentration, big.NewRat(int64(spec.Volume), 1))
+	mixed := func(node *mixNode) int {
+		for i, level := range node.state {
+			if level == spec.Volume && node.second[i].Cmp(second) == 0 {
+				return i
+			}
+		}
+		return -1
+	}
+
+	node, searchErr := mixSearch(&searchSpace{capacities: spec.Capacities, tags: tags}, liquids, maxSteps, mixed)
+	if searchErr != nil {
+		return nil, &AppError{
+			Error:   searchErr,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+	if node == nil {
+		return nil, &AppError{
+			Error: fmt.Errorf("there is no solution to mix %d at concentration %s within %d steps with jugs with %v",
+				spec.Volume, concentration.RatString(), maxSteps, spec.Capacities),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return &RiddleResponse{
+		Operations: node.operations(),
+		Jug:        tags[mixed(node)],
+		TotalSteps: node.depth,
+		Levels:     node.state.levels(tags),
+		Mixtures:   node.mixtures(tags, liquids),
+		Optimal:    true,
+	}, nil
+}
+
+func (spec *MixSpec) liquids() []string {
+	if len(spec.Liquids) > 0 {
+		return spec.Liquids
+	}
+	return defaultLiquids
+}
+
+/*
+ mixSearch runs a breadth-first search like search does, where every fill can draw either liquid and every pour moves
+      both liquids in the proportion the origin jug holds them. As the same levels can hold endless mixtures, the
+      search stops after maxSteps.
+
+ *mixNode: contains the first node where found returns the index of a jug, or nil if there is none
+ error: is not nil when there are too many mixtures to search
+*/
+func mixSearch(space *searchSpace, liquids []string, maxSteps int, found func(*mixNode) int) (*mixNode, error) {
+	root := &mixNode{state: make(jugState, len(space.capacities)), second: make([]*big.Rat, len(space.capacities))}
+	for i := range root.second {
+		root.second[i] = new(big.Rat)
+	}
+
+	indexes := map[string]int{}
+	for i, tag := range space.tags {
+		indexes[tag] = i
+	}
+
+	visited := map[string]bool{root.key(): true}
+	queue := []*mixNode{root}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node.depth == maxSteps {
+			continue
+		}
+
+		for _, next := range node.expand(space, indexes, liquids) {
+			key := next.key()
+			if visited[key] {
+				continue
+			}
+			visited[key] = true
+			if len(visited) > maxMixtures {
+				return nil, fmt.Errorf("there are more than %d mixtures within %d steps, try fewer steps", maxMixtures, maxSteps)
+			}
+
+			if found(next) >= 0 {
+				return next, nil
+			}
+			queue = append(queue, next)
+		}
+	}
+
+	return nil, nil
+}
+
+// expand returns the nodes reachable from node by applying a single operation, filling jugs with either liquid
+func (n *mixNode) expand(space *searchSpace, indexes map[string]int, liquids []string) []*mixNode {
+	var nodes []*mixNode
+
+	next := func(next *searchNode, second []*big.Rat, operation Operation) {
+		nodes = append(nodes, &mixNode{
+			state:     next.state,
+			second:    second,
+			parent:    n,
+			operation: operation,
+			depth:     next.depth,
+		})
+	}
+
+	for _, node := range space.expand(&searchNode{state: n.state, depth: n.depth}) {
+		operation := node.operation
+		amount := big.NewRat(int64(operation.WaterAmount), 1)
+
+		switch operation.OperationType {
+		case operationTypeFill:
+			jug := aws.StringValue(operation.Jug)
+			for k, liquid := range liquids {
+				second := n.copySecond()
+				moved := new(big.Rat)
+				if k == 1 {
+					moved = amount
+					second[indexes[jug]].Add(second[indexes[jug]], moved)
+				}
+				fill := operation
+				fill.Description = fmt.Sprintf("filling jug %s with %d of %s", jug, operation.WaterAmount, liquid)
+				fill.Composition = composition(liquids, amount, moved)
+				next(node, second, fill)
+			}
+		case operationTypeEmpty:
+			i := indexes[aws.StringValue(operation.Jug)]
+			second := n.copySecond()
+			second[i] = new(big.Rat)
+			operation.Composition = composition(liquids, amount, n.second[i])
+			next(node, second, operation)
+		case operationTypePour:
+			i := indexes[aws.StringValue(operation.JugOrigin)]
+			j := indexes[aws.StringValue(operation.JugDestination)]
+			// The water poured holds the same fraction of the second liquid as the origin jug
+			moved := new(big.Rat).Mul(n.second[i], big.NewRat(int64(operation.WaterAmount), int64(n.state[i])))
+			second := n.copySecond()
+			second[i].Sub(second[i], moved)
+			second[j].Add(second[j], moved)
+			operation.Composition = composition(liquids, amount, moved)
+			next(node, second, operation)
+		}
+	}
+
+	return nodes
+}
+
+func (n *mixNode) copySecond() []*big.Rat {
+	second := make([]*big.Rat, len(n.second))
+	for i, amount := range n.second {
+		second[i] = new(big.Rat).Set(amount)
+	}
+	return second
+}
+
+// composition returns the amount of every liquid in a volume holding second of the second liquid, leaving out the
+// liquids it doesn't hold
+func composition(liquids []string, volume, second *big.Rat) map[string]string {
+	amounts := map[string]string{}
+	if first := new(big.Rat).Sub(volume, second); first.Sign() > 0 {
+		amounts[liquids[0]] = first.RatString()
+	}
+	if second.Sign() > 0 {
+		amounts[liquids[1]] = second.RatString()
+	}
+	return amounts
+}
+
+// parseConcentration parses a fraction or a decimal number. Exponents are rejected, as they describe huge numbers
+// within a few characters
+func parseConcentration(concentration string) (*big.Rat, bool) {
+	if len(concentration) > maxConcentrationLength || strings.ContainsAny(concentration, "eE") {
+		return nil, false
+	}
+	return new(big.Rat).SetString(concentration)
+}
+
+// validateMixSpec returns the concentration of the second liquid the mixture must have
+func validateMixSpec(spec *MixSpec) (*big.Rat, *AppError) {
+	if len(spec.Capacities) == 0 {
+		return nil, &AppError{
+			Error:   errors.New("at least one jug is required"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if len(spec.Names) > 0 {
+		if err := validateJugNames(spec.Names, len(spec.Capacities)); err != nil {
+			return nil, &AppError{
+				Error:   err,
+				Message: "invalid parameters",
+				Code:    http.StatusBadRequest,
+			}
+		}
+	}
+
+	if len(spec.Liquids) > 0 {
+		if err := validateLiquids(spec.Liquids); err != nil {
+			return nil, &AppError{
+				Error:   err,
+				Message: "invalid parameters",
+				Code:    http.StatusBadRequest,
+			}
+		}
+	}
+
+	concentration, ok := parseConcentration(spec.Concentration)
+	if !ok || concentration.Sign() < 0 || concentration.Cmp(big.NewRat(1, 1)) > 0 {
+		return nil, &AppError{
+			Error:   errors.New("concentration must be a fraction between 0 and 1"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if spec.MaxSteps < 0 || spec.MaxSteps > maxMixSteps {
+		return nil, &AppError{
+			Error:   fmt.Errorf("max steps must be between 1 and %d, or 0 for the default of %d", maxMixSteps, defaultMixSteps),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if spec.Volume > maxOf(spec.Capacities...) {
+		return nil, &AppError{
+			Error:   fmt.Errorf("can't mix %d if it's bigger than jugs for %v", spec.Volume, spec.Capacities),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	// The volume held by jugs doesn't depend on the liquids, so it's still a combination of the capacities
+	if spec.Volume%gcdOf(spec.Capacities...) != 0 {
+		return nil, &AppError{
+			Error:   fmt.Errorf("there is no solution to mix %d with jugs with %v", spec.Volume, spec.Capacities),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return concentration, nil
+}
+
+func validateLiquids(liquids []string) error {
+	if len(liquids) != len(defaultLiquids) {
+		return fmt.Errorf("expected %d liquids but got %d", len(defaultLiquids), len(liquids))
+	}
+	if liquids[0] == "" || liquids[1] == "" {
+		return errors.New("liquid names can't be empty")
+	}
+	if liquids[0] == liquids[1] {
+		return fmt.Errorf("liquid %s is repeated", liquids[0])
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_MixRiddle(t *testing.T) {
+	type want struct {
+		output    *RiddleResponse
+		outputErr *AppError
+	}
+	tests := []struct {
+		name string
+		spec *MixSpec
+		want want
+	}{
+		{
+			name: "concentration is not a fraction",
+			spec: &MixSpec{
+				Capacities:    []int{2, 3},
+				Volume:        2,
+				Concentration: "half",
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("concentration must be a fraction between 0 and 1"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "concentration has an exponent",
+			spec: &MixSpec{
+				Capacities:    []int{2, 3},
+				Volume:        2,
+				Concentration: "1e-100000000",
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("concentration must be a fraction between 0 and 1"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "concentration is bigger than 1",
+			spec: &MixSpec{
+				Capacities:    []int{2, 3},
+				Volume:        2,
+				Concentration: "3/2",
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("concentration must be a fraction between 0 and 1"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "liquids are repeated",
+			spec: &MixSpec{
+				Capacities:    []int{2, 3},
+				Liquids:       []string{"water", "water"},
+				Volume:        2,
+				Concentration: "1/2",
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("liquid water is repeated"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "too many steps",
+			spec: &MixSpec{
+				Capacities:    []int{2, 3},
+				Volume:        2,
+				Concentration: "1/2",
+				MaxSteps:      15,
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("max steps must be between 1 and 14, or 0 for the default of 10"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "volume is bigger than every jug",
+			spec: &MixSpec{
+				Capacities:    []int{2, 3},
+				Volume:        4,
+				Concentration: "1/2",
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("can't mix %d if it's bigger than jugs for %v", 4, []int{2, 3}),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "gcd of every jug doesn't divide the volume",
+			spec: &MixSpec{
+				Capacities:    []int{2, 4},
+				Volume:        3,
+				Concentration: "1/2",
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("there is no solution to mix %d with jugs with %v", 3, []int{2, 4}),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "no solution within the steps",
+			spec: &MixSpec{
+				Capacities:    []int{3, 5},
+				Volume:        4,
+				Concentration: "1/2",
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("there is no solution to mix 4 at concentration 1/2 within 10 steps with jugs with %v", []int{3, 5}),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "success mixing 2 in halves with jugs with 2 and 3",
+			spec: &MixSpec{
+				Capacities:    []int{2, 3},
+				Names:         []string{xJugTag, yJugTag},
+				Volume:        2,
+				Concentration: "0.5",
+			},
+			want: want{
+				output: &RiddleResponse{
+					Operations: []Operation{
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String(xJugTag),
+							WaterAmount:   2,
+							Step:          1,
+							Description:   fmt.Sprintf("filling jug %s with 2 of water", xJugTag),
+							Composition:   map[string]string{"water": "2"},
+						},
+						{
+							OperationType:  operationTypePour,
+							JugOrigin:      aws.String(xJugTag),
+							JugDestination: aws.String(yJugTag),
+							WaterAmount:    2,
+							Step:           2,
+							Description:    fmt.Sprintf("pouring water from jug %s to %s", xJugTag, yJugTag),
+							Composition:    map[string]string{"water": "2"},
+						},
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String(xJugTag),
+							WaterAmount:   2,
+							Step:          3,
+							Description:   fmt.Sprintf("filling jug %s with 2 of water", xJugTag),
+							Composition:   map[string]string{"water": "2"},
+						},
+						{
+							OperationType:  operationTypePour,
+							JugOrigin:      aws.String(xJugTag),
+							JugDestination: aws.String(yJugTag),
+							WaterAmount:    1,
+							Step:           4,
+							Description:    fmt.Sprintf("pouring water from jug %s to %s", xJugTag, yJugTag),
+							Composition:    map[string]string{"water": "1"},
+						},
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String(xJugTag),
+							WaterAmount:   1,
+							Step:          5,
+							Description:   fmt.Sprintf("filling jug %s with 1 of syrup", xJugTag),
+							Composition:   map[string]string{"syrup": "1"},
+						},
+					},
+					Jug:        xJugTag,
+					TotalSteps: 5,
+					Levels:     map[string]int{xJugTag: 2, yJugTag: 3},
+					Mixtures: map[string]map[string]string{
+						xJugTag: {"water": "1", "syrup": "1"},
+						yJugTag: {"water": "3"},
+					},
+					Optimal: true,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &service{}
+			output, outputErr := svc.MixRiddle(tt.spec)
+
+			a := assert.New(t)
+			a.Equal(tt.want.output, output)
+			a.Equal(tt.want.outputErr, outputErr)
+		})
+	}
+}
+
+func TestService_MixRiddle_Concentration(t *testing.T) {
+	svc := &service{}
+	output, outputErr := svc.MixRiddle(&MixSpec{
+		Capacities:    []int{3, 5},
+		Liquids:       []string{"juice", "soda"},
+		Volume:        3,
+		Concentration: "1/5",
+		MaxSteps:      8,
+	})
+
+	a := assert.New(t)
+	a.Nil(outputErr)
+	a.Equal(map[string]string{"juice": "12/5", "soda": "3/5"}, output.Mixtures[output.Jug])
+}
@@ -0,0 +1,109 @@
+package service
+
+// NearestResponse suggests the amounts closest to z that can be measured, when z itself can't
+type NearestResponse struct {
+	// Exact tells whether z itself can be measured, in which case its plan is the only one
+	Exact bool `json:"exact"`
+	// Plans contains the plan of the closest amount below z and the closest one above it, when there are any
+	Plans []NearestPlan `json:"plans,omitempty"`
+}
+
+// NearestPlan is the shortest plan that measures amount
+type NearestPlan struct {
+	Amount int `json:"amount"`
+	RiddleResponse
+}
+
+func (s *service) NearestRiddle(x, y, z int, maxSteps *int) (*NearestResponse, *AppError) {
+	// Without a budget every reachable state is visited, and there are about twice as many as the water jugs hold
+	if maxSteps == nil {
+		if err := validateSearchCapacity(x, y); err != nil {
+			return nil, err
+		}
+	}
+
+	tags := []string{xJugTag, yJugTag}
+	measured := nearestSearch(&searchSpace{capacities: []int{x, y}, tags: tags}, jugState{0, 0}, maxSteps)
+
+	plan := func(amount int) NearestPlan {
+		node := measured[amount]
+		return NearestPlan{
+			Amount: amount,
+			RiddleResponse: RiddleResponse{
+				Operations: node.operations(),
+				Jug:        tags[jugWith(node.state, amount)],
+				TotalSteps: node.depth,
+				Optimal:    true,
+			},
+		}
+	}
+
+	if _, ok := measured[z]; ok {
+		return &NearestResponse{
+			Exact: true,
+			Plans: []NearestPlan{plan(z)},
+		}, nil
+	}
+
+	below, above := 0, 0
+	for amount := range measured {
+		if amount < z && amount > below {
+			below = amount
+		}
+		if amount > z && (above == 0 || amount < above) {
+			above = amount
+		}
+	}
+
+	response := &NearestResponse{}
+	if below > 0 {
+		response.Plans = append(response.Plans, plan(below))
+	}
+	if above > 0 {
+		response.Plans = append(response.Plans, plan(above))
+	}
+	return response, nil
+}
+
+/*
+ nearestSearch runs a breadth-first search like search does, visiting every state reachable within maxSteps, or every
+      reachable state when maxSteps is nil, instead of stopping at a goal.
+
+ map[int]*searchNode: contains, for every positive amount held by a jug of a visited state, the first node where some
+      jug holds it, which is reached through the shortest possible plan
+*/
+func nearestSearch(space *searchSpace, initial jugState, maxSteps *int) map[int]*searchNode {
+	measured := map[int]*searchNode{}
+	record := func(node *searchNode) {
+		for _, level := range node.state {
+			if _, ok := measured[level]; !ok && level > 0 {
+				measured[level] = node
+			}
+		}
+	}
+
+	root := &searchNode{state: initial}
+	record(root)
+	leastDrawn := map[string]int{initial.key(): 0}
+	queue := []*searchNode{root}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if maxSteps != nil && node.depth == *maxSteps {
+			continue
+		}
+
+		for _, next := range space.expand(node) {
+			key := next.state.key()
+			if !space.improves(leastDrawn, key, next.drawn) {
+				continue
+			}
+			leastDrawn[key] = next.drawn
+			record(next)
+			queue = append(queue, next)
+		}
+	}
+
+	return measured
+}
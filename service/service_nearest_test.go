@@ -0,0 +1,254 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_NearestRiddle(t *testing.T) {
+	type input struct {
+		x        int
+		y        int
+		z        int
+		maxSteps *int
+	}
+	type want struct {
+		output    *NearestResponse
+		outputErr *AppError
+	}
+	tests := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			name:  "jugs are too big to search without max steps",
+			input: input{x: 60001, y: 50000, z: 4},
+			want: want{
+				outputErr: &AppError{
+					Error: fmt.Errorf("jugs are too big to search the shortest plan, they can't hold more than %d together",
+						maxSearchCapacity),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name:  "success with the amounts around z that gcd doesn't divide",
+			input: input{x: 4, y: 6, z: 5},
+			want: want{
+				output: &NearestResponse{
+					Plans: []NearestPlan{
+						{
+							Amount: 4,
+							RiddleResponse: RiddleResponse{
+								Operations: []Operation{
+									{
+										OperationType: operationTypeFill,
+										Jug:           aws.String(xJugTag),
+										WaterAmount:   4,
+										Step:          1,
+										Description:   fmt.Sprintf("filling jug %s with 4 capacity", xJugTag),
+									},
+								},
+								Jug:        xJugTag,
+								TotalSteps: 1,
+								Optimal:    true,
+							},
+						},
+						{
+							Amount: 6,
+							RiddleResponse: RiddleResponse{
+								Operations: []Operation{
+									{
+										OperationType: operationTypeFill,
+										Jug:           aws.String(yJugTag),
+										WaterAmount:   6,
+										Step:          1,
+										Description:   fmt.Sprintf("filling jug %s with 6 capacity", yJugTag),
+									},
+								},
+								Jug:        yJugTag,
+								TotalSteps: 1,
+								Optimal:    true,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "success with the biggest amount when z is bigger than jugs",
+			input: input{x: 3, y: 5, z: 9},
+			want: want{
+				output: &NearestResponse{
+					Plans: []NearestPlan{
+						{
+							Amount: 5,
+							RiddleResponse: RiddleResponse{
+								Operations: []Operation{
+									{
+										OperationType: operationTypeFill,
+										Jug:           aws.String(yJugTag),
+										WaterAmount:   5,
+										Step:          1,
+										Description:   fmt.Sprintf("filling jug %s with 5 capacity", yJugTag),
+									},
+								},
+								Jug:        yJugTag,
+								TotalSteps: 1,
+								Optimal:    true,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "success with the amounts around z within max steps",
+			input: input{x: 3, y: 5, z: 4, maxSteps: aws.Int(3)},
+			want: want{
+				output: &NearestResponse{
+					Plans: []NearestPlan{
+						{
+							Amount: 3,
+							RiddleResponse: RiddleResponse{
+								Operations: []Operation{
+									{
+										OperationType: operationTypeFill,
+										Jug:           aws.String(xJugTag),
+										WaterAmount:   3,
+										Step:          1,
+										Description:   fmt.Sprintf("filling jug %s with 3 capacity", xJugTag),
+									},
+								},
+								Jug:        xJugTag,
+								TotalSteps: 1,
+								Optimal:    true,
+							},
+						},
+						{
+							Amount: 5,
+							RiddleResponse: RiddleResponse{
+								Operations: []Operation{
+									{
+										OperationType: operationTypeFill,
+										Jug:           aws.String(yJugTag),
+										WaterAmount:   5,
+										Step:          1,
+										Description:   fmt.Sprintf("filling jug %s with 5 capacity", yJugTag),
+									},
+								},
+								Jug:        yJugTag,
+								TotalSteps: 1,
+								Optimal:    true,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "success with huge jugs within max steps",
+			input: input{x: 1, y: 1000000000, z: 500000000, maxSteps: aws.Int(2)},
+			want: want{
+				output: &NearestResponse{
+					Plans: []NearestPlan{
+						{
+							Amount: 1,
+							RiddleResponse: RiddleResponse{
+								Operations: []Operation{
+									{
+										OperationType: operationTypeFill,
+										Jug:           aws.String(xJugTag),
+										WaterAmount:   1,
+										Step:          1,
+										Description:   fmt.Sprintf("filling jug %s with 1 capacity", xJugTag),
+									},
+								},
+								Jug:        xJugTag,
+								TotalSteps: 1,
+								Optimal:    true,
+							},
+						},
+						{
+							Amount: 999999999,
+							RiddleResponse: RiddleResponse{
+								Operations: []Operation{
+									{
+										OperationType: operationTypeFill,
+										Jug:           aws.String(yJugTag),
+										WaterAmount:   1000000000,
+										Step:          1,
+										Description:   fmt.Sprintf("filling jug %s with 1000000000 capacity", yJugTag),
+									},
+									{
+										OperationType:  operationTypePour,
+										JugOrigin:      aws.String(yJugTag),
+										JugDestination: aws.String(xJugTag),
+										WaterAmount:    1,
+										Step:           2,
+										Description:    fmt.Sprintf("pouring water from jug %s to %s", yJugTag, xJugTag),
+									},
+								},
+								Jug:        yJugTag,
+								TotalSteps: 2,
+								Optimal:    true,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "success with z when it can be measured",
+			input: input{x: 2, y: 3, z: 1},
+			want: want{
+				output: &NearestResponse{
+					Exact: true,
+					Plans: []NearestPlan{
+						{
+							Amount: 1,
+							RiddleResponse: RiddleResponse{
+								Operations: []Operation{
+									{
+										OperationType: operationTypeFill,
+										Jug:           aws.String(yJugTag),
+										WaterAmount:   3,
+										Step:          1,
+										Description:   fmt.Sprintf("filling jug %s with 3 capacity", yJugTag),
+									},
+									{
+										OperationType:  operationTypePour,
+										JugOrigin:      aws.String(yJugTag),
+										JugDestination: aws.String(xJugTag),
+										WaterAmount:    2,
+										Step:           2,
+										Description:    fmt.Sprintf("pouring water from jug %s to %s", yJugTag, xJugTag),
+									},
+								},
+								Jug:        yJugTag,
+								TotalSteps: 2,
+								Optimal:    true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &service{}
+			output, outputErr := svc.NearestRiddle(tt.input.x, tt.input.y, tt.input.z, tt.input.maxSteps)
+
+			a := assert.New(t)
+			a.Equal(tt.want.output, output)
+			a.Equal(tt.want.outputErr, outputErr)
+		})
+	}
+}
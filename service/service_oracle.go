@@ -0,0 +1,210 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"net/http"
+)
+
+type OracleResponse struct {
+	Jug        string     `json:"jug,omitempty"`
+	TotalSteps int        `json:"total_steps,omitempty"`
+	State      *JugsState `json:"state,omitempty"`
+}
+
+// JugsState describes the jugs right after a step of the solution
+type JugsState struct {
+	Step      int            `json:"step"`
+	Operation *Operation     `json:"operation,omitempty"`
+	Levels    map[string]int `json:"levels"`
+}
+
+/*
+ pourOracle describes the solution that pour finds for jug1 and jug2 without simulating it. While pouring from jug1
+      into jug2, pours only stop when jug1 becomes empty or jug2 becomes full. Measuring the total water poured so
+      far, that happens on every multiple of jug1Cap and every multiple of jug2Cap respectively, so the n-th pour ends
+      on the n-th of those "events". The levels of both jugs after each pour and the amount of steps taken so far only
+      depend on the event, and the event where z is measured is found with the extended Euclidean algorithm.
+*/
+type pourOracle struct {
+	jug1Cap int
+	jug1Tag string
+	jug2Cap int
+	jug2Tag string
+	lcm     int
+	// final is the event where z is measured, or 0 when the first fill already measures it
+	final int
+	jug   string
+}
+
+func newPourOracle(jug1Cap int, jug1Tag string, jug2Cap int, jug2Tag string, z int) *pourOracle {
+	g := gcd(jug1Cap, jug2Cap)
+	o := &pourOracle{
+		jug1Cap: jug1Cap,
+		jug1Tag: jug1Tag,
+		jug2Cap: jug2Cap,
+		jug2Tag: jug2Tag,
+		lcm:     jug1Cap / g * jug2Cap,
+	}
+
+	if z == jug1Cap {
+		o.jug = jug1Tag
+		return o
+	}
+
+	// z is measured in jug1 after a pour that filled jug2, i.e. on a multiple of jug2Cap that is -z modulo jug1Cap
+	if z < jug1Cap {
+		i := mulMod(modInverse(jug2Cap/g, jug1Cap/g), (jug1Cap-z)/g, jug1Cap/g)
+		o.final = jug2Cap * i
+		o.jug = jug1Tag
+	}
+
+	// z is measured in jug2 on the first event that is z modulo jug2Cap. Unless z is jug2Cap, it's a multiple of jug1Cap
+	if z <= jug2Cap {
+		event := jug2Cap
+		if z < jug2Cap {
+			i := mulMod(modInverse(jug1Cap/g, jug2Cap/g), z/g, jug2Cap/g)
+			event = jug1Cap * i
+		}
+		if o.final == 0 || event < o.final {
+			o.final = event
+			o.jug = jug2Tag
+		}
+	}
+
+	return o
+}
+
+// pourStep returns the step of the pour that ends on event
+func (o *pourOracle) pourStep(event int) int {
+	pours := event/o.jug1Cap + event/o.jug2Cap - event/o.lcm
+	fills := (event - 1) / o.jug1Cap
+	empties := (event - 1) / o.jug2Cap
+	return 1 + pours + fills + empties
+}
+
+func (o *pourOracle) totalSteps() int {
+	if o.final == 0 {
+		return 1
+	}
+	return o.pourStep(o.final)
+}
+
+// lastEvent returns the last event up to poured, or 0 if there is none
+func (o *pourOracle) lastEvent(poured int) int {
+	return max(poured/o.jug1Cap*o.jug1Cap, poured/o.jug2Cap*o.jug2Cap)
+}
+
+// state returns the state of the jugs right after step, which must be within the solution
+func (o *pourOracle) state(step int) *JugsState {
+	if step == 0 {
+		return o.jugsState(step, nil, 0, 0)
+	}
+
+	// Find the last event whose pour happens up to step
+	low, high := 0, o.final
+	for low < high {
+		mid := low + (high-low+1)/2
+		if event := o.lastEvent(mid); event == 0 || o.pourStep(event) <= step {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+
+	event := o.lastEvent(low)
+	if event == 0 {
+		operation := fillOperation(o.jug1Tag, o.jug1Cap, o.jug1Cap, step)
+		return o.jugsState(step, &operation, o.jug1Cap, 0)
+	}
+
+	jug1 := ceilDiv(event, o.jug1Cap)*o.jug1Cap - event
+	jug2 := event - (event-1)/o.jug2Cap*o.jug2Cap
+	remaining := step - o.pourStep(event)
+
+	if remaining == 0 {
+		operation := pourOperation(o.jug1Tag, o.jug2Tag, event-o.lastEvent(event-1), step)
+		return o.jugsState(step, &operation, jug1, jug2)
+	}
+
+	// After the pour, jug1 is filled if it became empty and then jug2 is emptied if it became full
+	if jug1 == 0 {
+		jug1 = o.jug1Cap
+		remaining--
+		if remaining == 0 {
+			operation := fillOperation(o.jug1Tag, o.jug1Cap, o.jug1Cap, step)
+			return o.jugsState(step, &operation, jug1, jug2)
+		}
+	}
+
+	operation := emptyOperation(o.jug2Tag, o.jug2Cap, o.jug2Cap, step)
+	return o.jugsState(step, &operation, jug1, 0)
+}
+
+func (o *pourOracle) jugsState(step int, operation *Operation, jug1, jug2 int) *JugsState {
+	return &JugsState{
+		Step:      step,
+		Operation: operation,
+		Levels: map[string]int{
+			o.jug1Tag: jug1,
+			o.jug2Tag: jug2,
+		},
+	}
+}
+
+func (s *service) OracleRiddle(x, y, z int, step *int) (*OracleResponse, *AppError) {
+	oracle, err := getOracle(x, y, z)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &OracleResponse{
+		Jug:        oracle.jug,
+		TotalSteps: oracle.totalSteps(),
+	}
+
+	if step != nil {
+		if *step > response.TotalSteps {
+			return nil, &AppError{
+				Error:   fmt.Errorf("step %d is beyond the %d steps of the solution", *step, response.TotalSteps),
+				Message: "invalid parameters",
+				Code:    http.StatusBadRequest,
+			}
+		}
+		response.State = oracle.state(*step)
+	}
+
+	return response, nil
+}
+
+// getOracle returns the oracle of the solution that getOperations finds, without simulating it
+func getOracle(x, y, z int) (*pourOracle, *AppError) {
+	if err := validateRiddle(x, y, z); err != nil {
+		return nil, err
+	}
+
+	// Events go up to the lcm of both jugs, which must not overflow
+	if hi, lo := bits.Mul(uint(x), uint(y)); hi != 0 || int(lo) < 0 {
+		return nil, &AppError{
+			Error:   errors.New("jugs are too big to describe their solution"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	smallerJug, smallerJugTag := x, xJugTag
+	biggerJug, biggerJugTag := y, yJugTag
+	if x > y {
+		smallerJug, smallerJugTag = y, yJugTag
+		biggerJug, biggerJugTag = x, xJugTag
+	}
+
+	// Same as getOperations, the first scenario is kept only when it's shorter
+	first := newPourOracle(biggerJug, biggerJugTag, smallerJug, smallerJugTag, z)
+	second := newPourOracle(smallerJug, smallerJugTag, biggerJug, biggerJugTag, z)
+	if first.totalSteps() < second.totalSteps() {
+		return first, nil
+	}
+	return second, nil
+}
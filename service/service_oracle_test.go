@@ -0,0 +1,178 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_OracleRiddle(t *testing.T) {
+	type args struct {
+		x    int
+		y    int
+		z    int
+		step *int
+	}
+	type want struct {
+		output    *OracleResponse
+		outputErr *AppError
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "z is bigger than x and y",
+			args: args{
+				x: 1,
+				y: 2,
+				z: 3,
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("can't measure %d if it's bigger than jugs for %d and %d", 3, 1, 2),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "step is beyond the solution",
+			args: args{
+				x:    3,
+				y:    5,
+				z:    4,
+				step: aws.Int(7),
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("step %d is beyond the %d steps of the solution", 7, 6),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "success with x = 3, y = 5 and z = 4",
+			args: args{
+				x:    3,
+				y:    5,
+				z:    4,
+				step: aws.Int(4),
+			},
+			want: want{
+				output: &OracleResponse{
+					Jug:        yJugTag,
+					TotalSteps: 6,
+					State: &JugsState{
+						Step: 4,
+						Operation: &Operation{
+							OperationType:  operationTypePour,
+							JugOrigin:      aws.String(yJugTag),
+							JugDestination: aws.String(xJugTag),
+							WaterAmount:    2,
+							Step:           4,
+							Description:    fmt.Sprintf("pouring water from jug %s to %s", yJugTag, xJugTag),
+						},
+						Levels: map[string]int{xJugTag: 2, yJugTag: 0},
+					},
+				},
+			},
+		},
+		{
+			name: "success before the first step",
+			args: args{
+				x:    3,
+				y:    5,
+				z:    4,
+				step: aws.Int(0),
+			},
+			want: want{
+				output: &OracleResponse{
+					Jug:        yJugTag,
+					TotalSteps: 6,
+					State: &JugsState{
+						Levels: map[string]int{xJugTag: 0, yJugTag: 0},
+					},
+				},
+			},
+		},
+		{
+			name: "success with x = 1, y = 10^9 and z = 5 * 10^8",
+			args: args{
+				x:    1,
+				y:    1000000000,
+				z:    500000000,
+				step: aws.Int(5000001),
+			},
+			want: want{
+				output: &OracleResponse{
+					Jug:        yJugTag,
+					TotalSteps: 1000000000,
+					State: &JugsState{
+						Step: 5000001,
+						Operation: &Operation{
+							OperationType: operationTypeFill,
+							Jug:           aws.String(xJugTag),
+							WaterAmount:   1,
+							Step:          5000001,
+							Description:   fmt.Sprintf("filling jug %s with 1 capacity", xJugTag),
+						},
+						Levels: map[string]int{xJugTag: 1, yJugTag: 2500000},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &service{}
+			output, outputErr := svc.OracleRiddle(tt.args.x, tt.args.y, tt.args.z, tt.args.step)
+
+			a := assert.New(t)
+			a.Equal(tt.want.output, output)
+			a.Equal(tt.want.outputErr, outputErr)
+		})
+	}
+}
+
+func TestService_OracleRiddle_MatchesRiddle(t *testing.T) {
+	svc := &service{}
+	a := assert.New(t)
+
+	for x := 1; x <= 12; x++ {
+		for y := 1; y <= 12; y++ {
+			for z := 1; z <= 12; z++ {
+				riddle, riddleErr := svc.Riddle(x, y, z)
+				oracle, oracleErr := svc.OracleRiddle(x, y, z, nil)
+				if !a.Equal(riddleErr, oracleErr, "x = %d, y = %d and z = %d", x, y, z) || riddleErr != nil {
+					continue
+				}
+				a.Equal(riddle.TotalSteps, oracle.TotalSteps, "x = %d, y = %d and z = %d", x, y, z)
+				a.Equal(riddle.Jug, oracle.Jug, "x = %d, y = %d and z = %d", x, y, z)
+
+				levels := map[string]int{xJugTag: 0, yJugTag: 0}
+				for _, operation := range riddle.Operations {
+					switch operation.OperationType {
+					case operationTypeFill:
+						levels[*operation.Jug] += operation.WaterAmount
+					case operationTypeEmpty:
+						levels[*operation.Jug] = 0
+					case operationTypePour:
+						levels[*operation.JugOrigin] -= operation.WaterAmount
+						levels[*operation.JugDestination] += operation.WaterAmount
+					}
+
+					step := operation.Step
+					state, _ := svc.OracleRiddle(x, y, z, &step)
+					a.Equal(levels, state.State.Levels, "x = %d, y = %d, z = %d and step = %d", x, y, z, step)
+					a.Equal(operation, *state.State.Operation, "x = %d, y = %d, z = %d and step = %d", x, y, z, step)
+				}
+			}
+		}
+	}
+}
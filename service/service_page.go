@@ -0,0 +1,92 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func (s *service) RiddlePage(x, y, z int, cursor string, limit int) (*RiddleResponse, *AppError) {
+	oracle, err := getOracle(x, y, z)
+	if err != nil {
+		return nil, err
+	}
+
+	from := 1
+	if cursor != "" {
+		riddle, step, valid := decodeCursor(cursor)
+		if !valid {
+			return nil, &AppError{
+				Error:   errors.New("cursor is not valid"),
+				Message: "invalid parameters",
+				Code:    http.StatusBadRequest,
+			}
+		}
+		// Steps only make sense within the solution the cursor was returned for
+		if riddle != [3]int{x, y, z} {
+			return nil, &AppError{
+				Error:   errors.New("cursor belongs to the solution of another riddle"),
+				Message: "invalid parameters",
+				Code:    http.StatusBadRequest,
+			}
+		}
+		from = step
+	}
+
+	totalSteps := oracle.totalSteps()
+	if from > totalSteps {
+		return nil, &AppError{
+			Error:   errors.New("cursor is beyond the solution"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	// Only the operations of the page are generated, each of them straight from the oracle
+	to := min(from+limit-1, totalSteps)
+	operations := make([]Operation, 0, to-from+1)
+	for step := from; step <= to; step++ {
+		operations = append(operations, *oracle.state(step).Operation)
+	}
+
+	response := &RiddleResponse{
+		Operations: operations,
+		Jug:        oracle.jug,
+		TotalSteps: totalSteps,
+	}
+	if to < totalSteps {
+		response.NextCursor = encodeCursor(x, y, z, to+1)
+	}
+	return response, nil
+}
+
+// encodeCursor returns an opaque cursor pointing to step of the solution to measure z with jugs with x and y
+func encodeCursor(x, y, z, step int) string {
+	fields := []string{strconv.Itoa(x), strconv.Itoa(y), strconv.Itoa(z), strconv.Itoa(step)}
+	return base64.RawURLEncoding.EncodeToString([]byte(strings.Join(fields, ":")))
+}
+
+// decodeCursor returns the x, y and z of the riddle the cursor belongs to and the step it points to
+func decodeCursor(cursor string) ([3]int, int, bool) {
+	var riddle [3]int
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return riddle, 0, false
+	}
+	fields := strings.Split(string(decoded), ":")
+	if len(fields) != len(riddle)+1 {
+		return riddle, 0, false
+	}
+	for i := range riddle {
+		if riddle[i], err = strconv.Atoi(fields[i]); err != nil {
+			return riddle, 0, false
+		}
+	}
+	step, err := strconv.Atoi(fields[len(riddle)])
+	if err != nil || step < 1 {
+		return riddle, 0, false
+	}
+	return riddle, step, true
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_RiddlePage(t *testing.T) {
+	type args struct {
+		x      int
+		y      int
+		z      int
+		cursor string
+		limit  int
+	}
+	type want struct {
+		from       int
+		to         int
+		nextCursor string
+		outputErr  *AppError
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "z is bigger than x and y",
+			args: args{
+				x:     1,
+				y:     2,
+				z:     3,
+				limit: 50,
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("can't measure %d if it's bigger than jugs for %d and %d", 3, 1, 2),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "cursor is not valid",
+			args: args{
+				x:      3,
+				y:      5,
+				z:      4,
+				cursor: "step",
+				limit:  50,
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("cursor is not valid"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "cursor belongs to another riddle",
+			args: args{
+				x:      3,
+				y:      5,
+				z:      4,
+				cursor: encodeCursor(3, 5, 2, 3),
+				limit:  50,
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("cursor belongs to the solution of another riddle"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "cursor is beyond the solution",
+			args: args{
+				x:      3,
+				y:      5,
+				z:      4,
+				cursor: encodeCursor(3, 5, 4, 7),
+				limit:  50,
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("cursor is beyond the solution"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "success with the first page",
+			args: args{
+				x:     3,
+				y:     5,
+				z:     4,
+				limit: 4,
+			},
+			want: want{
+				from:       1,
+				to:         4,
+				nextCursor: encodeCursor(3, 5, 4, 5),
+			},
+		},
+		{
+			name: "success with the last page",
+			args: args{
+				x:      3,
+				y:      5,
+				z:      4,
+				cursor: encodeCursor(3, 5, 4, 5),
+				limit:  4,
+			},
+			want: want{
+				from: 5,
+				to:   6,
+			},
+		},
+		{
+			name: "success with a page in the middle of a huge solution",
+			args: args{
+				x:      1,
+				y:      1000000000,
+				z:      500000000,
+				cursor: encodeCursor(1, 1000000000, 500000000, 999999950),
+				limit:  50,
+			},
+			want: want{
+				from:       999999950,
+				to:         999999999,
+				nextCursor: encodeCursor(1, 1000000000, 500000000, 1000000000),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &service{}
+			output, outputErr := svc.RiddlePage(tt.args.x, tt.args.y, tt.args.z, tt.args.cursor, tt.args.limit)
+
+			a := assert.New(t)
+			a.Equal(tt.want.outputErr, outputErr)
+			if tt.want.outputErr != nil {
+				a.Nil(output)
+				return
+			}
+
+			oracle, _ := svc.OracleRiddle(tt.args.x, tt.args.y, tt.args.z, nil)
+			a.Equal(oracle.TotalSteps, output.TotalSteps)
+			a.Equal(oracle.Jug, output.Jug)
+			a.Equal(tt.want.nextCursor, output.NextCursor)
+
+			a.Len(output.Operations, tt.want.to-tt.want.from+1)
+			for i, operation := range output.Operations {
+				step := tt.want.from + i
+				state, _ := svc.OracleRiddle(tt.args.x, tt.args.y, tt.args.z, &step)
+				a.Equal(*state.State.Operation, operation)
+			}
+
+			if tt.args.x < 10 && tt.args.y < 10 {
+				riddle, _ := svc.Riddle(tt.args.x, tt.args.y, tt.args.z)
+				a.Equal(riddle.Operations[tt.want.from-1:tt.want.to], output.Operations)
+			}
+		})
+	}
+}
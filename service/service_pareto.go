@@ -0,0 +1,156 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+)
+
+// ParetoPlan is a plan that no other plan improves in steps, water used and water wasted at the same time
+type ParetoPlan struct {
+	RiddleResponse
+	// WaterUsed is the amount of water drawn from the tap by fill operations
+	WaterUsed int `json:"water_used"`
+	// WaterWasted is the amount of water thrown away by empty and tilt operations
+	WaterWasted int `json:"water_wasted"`
+}
+
+type ParetoResponse struct {
+	Plans []ParetoPlan `json:"plans,omitempty"`
+}
+
+// paretoLabel is a node reached by the search together with the water used and wasted to reach it
+type paretoLabel struct {
+	node   *searchNode
+	used   int
+	wasted int
+}
+
+// dominates returns whether l is at least as good as other in steps, water used and water wasted
+func (l *paretoLabel) dominates(other *paretoLabel) bool {
+	return l.node.depth <= other.node.depth && l.used <= other.used && l.wasted <= other.wasted
+}
+
+func (s *service) ParetoRiddle(spec *RiddleSpec) (*ParetoResponse, *AppError) {
+	if err := validateRiddleSpec(spec); err != nil {
+		return nil, err
+	}
+
+	if spec.Costs != nil {
+		return nil, &AppError{
+			Error:   errors.New("costs can't be used when comparing plans by steps and water"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	tags := spec.tags()
+	labels, err := paretoSearch(spec.space(), spec.initial(), spec.goal())
+	if err != nil {
+		return nil, &AppError{
+			Error:   err,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+	if len(labels) == 0 {
+		return nil, spec.unsolvable()
+	}
+
+	plans := make([]ParetoPlan, len(labels))
+	for i, label := range labels {
+		plans[i] = ParetoPlan{
+			RiddleResponse: RiddleResponse{
+				Operations: label.node.operations(),
+				Jug:        spec.jug(label.node.state, tags),
+				TotalSteps: label.node.depth,
+				Levels:     label.node.state.levels(tags),
+			},
+			WaterUsed:   label.used,
+			WaterWasted: label.wasted,
+		}
+	}
+
+	return &ParetoResponse{
+		Plans: plans,
+	}, nil
+}
+
+/*
+ paretoSearch runs a breadth-first search like search does, but keeps looking for plans after the first state accepted
+      by goal is found. A state is expanded again whenever it's reached using less water than before, as the water
+      held by the jugs is always the water used minus the water wasted, so that plan is better in water used and
+      wasted despite taking more steps.
+
+ []*paretoLabel: contains every plan in the Pareto front, sorted by steps
+ error: is not nil when there are too many states to search
+*/
+func paretoSearch(space *searchSpace, initial jugState, goal func(jugState) bool) ([]*paretoLabel, error) {
+	var front []*paretoLabel
+
+	root := &paretoLabel{node: &searchNode{state: initial}}
+	leastUsed := map[string]int{initial.key(): 0}
+	queue := []*paretoLabel{root}
+	// States are expanded again when they're reached using less water, so every expansion counts
+	expanded := 0
+
+	for len(queue) > 0 {
+		label := queue[0]
+		queue = queue[1:]
+
+		if dominated(front, label) {
+			continue
+		}
+
+		if goal(label.node.state) {
+			front = append(undominated(front, label), label)
+			continue
+		}
+
+		if expanded++; expanded > maxSearchStates {
+			return nil, errTooManyStates()
+		}
+		for _, next := range space.expand(label.node) {
+			nextLabel := &paretoLabel{
+				node:   next,
+				used:   label.used,
+				wasted: label.wasted,
+			}
+			switch next.operation.OperationType {
+			case operationTypeFill:
+				nextLabel.used += next.operation.WaterAmount
+			case operationTypeEmpty, operationTypeTilt:
+				nextLabel.wasted += next.operation.WaterAmount
+			}
+
+			key := next.state.key()
+			if used, ok := leastUsed[key]; ok && used <= nextLabel.used {
+				continue
+			}
+			leastUsed[key] = nextLabel.used
+			queue = append(queue, nextLabel)
+		}
+	}
+
+	return front, nil
+}
+
+// dominated returns whether any plan of the front is at least as good as label
+func dominated(front []*paretoLabel, label *paretoLabel) bool {
+	for _, plan := range front {
+		if plan.dominates(label) {
+			return true
+		}
+	}
+	return false
+}
+
+// undominated returns the plans of the front that label is not at least as good as
+func undominated(front []*paretoLabel, label *paretoLabel) []*paretoLabel {
+	var plans []*paretoLabel
+	for _, plan := range front {
+		if !label.dominates(plan) {
+			plans = append(plans, plan)
+		}
+	}
+	return plans
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_ParetoRiddle(t *testing.T) {
+	type plan struct {
+		jug         string
+		totalSteps  int
+		levels      map[string]int
+		waterUsed   int
+		waterWasted int
+	}
+	type want struct {
+		plans     []plan
+		outputErr *AppError
+	}
+	tests := []struct {
+		name string
+		spec *RiddleSpec
+		want want
+	}{
+		{
+			name: "costs can't be compared",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Z:          4,
+				Costs:      map[OperationType]OperationCost{},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("costs can't be used when comparing plans by steps and water"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "gcd of every jug doesn't divide z",
+			spec: &RiddleSpec{
+				Capacities: []int{2, 4},
+				Z:          3,
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("there is no solution to measure %d with jugs with %v", 3, []int{2, 4}),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "success with a shorter plan and a plan using less water",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Names:      []string{xJugTag, yJugTag},
+				Z:          4,
+			},
+			want: want{
+				plans: []plan{
+					{
+						jug:         yJugTag,
+						totalSteps:  6,
+						levels:      map[string]int{xJugTag: 3, yJugTag: 4},
+						waterUsed:   10,
+						waterWasted: 3,
+					},
+					{
+						jug:         yJugTag,
+						totalSteps:  8,
+						levels:      map[string]int{xJugTag: 0, yJugTag: 4},
+						waterUsed:   9,
+						waterWasted: 5,
+					},
+				},
+			},
+		},
+		{
+			name: "success discarding a plan of the same length using more water",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5, 7},
+				Z:          1,
+			},
+			want: want{
+				plans: []plan{
+					{
+						jug:         "1",
+						totalSteps:  4,
+						levels:      map[string]int{"1": 1, "2": 5, "3": 0},
+						waterUsed:   6,
+						waterWasted: 0,
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &service{}
+			output, outputErr := svc.ParetoRiddle(tt.spec)
+
+			a := assert.New(t)
+			a.Equal(tt.want.outputErr, outputErr)
+
+			if tt.want.plans == nil {
+				a.Nil(output)
+				return
+			}
+
+			a.Len(output.Plans, len(tt.want.plans))
+			for i, p := range output.Plans {
+				a.Equal(tt.want.plans[i].jug, p.Jug)
+				a.Equal(tt.want.plans[i].totalSteps, p.TotalSteps)
+				a.Len(p.Operations, p.TotalSteps)
+				a.Equal(tt.want.plans[i].levels, p.Levels)
+				a.Equal(tt.want.plans[i].waterUsed, p.WaterUsed)
+				a.Equal(tt.want.plans[i].waterWasted, p.WaterWasted)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// RecipeSpec describes a riddle where several amounts are measured one after another, handing off each of them
+type RecipeSpec struct {
+	RiddleSpec
+	// Targets contains the amounts to deliver, in the order they are delivered
+	Targets []int `json:"targets,omitempty"`
+}
+
+// recipeNode is a node reached by the recipe search together with the amount of targets delivered so far
+type recipeNode struct {
+	node      *searchNode
+	delivered int
+}
+
+func (s *service) RecipeRiddle(spec *RecipeSpec) (*RiddleResponse, *AppError) {
+	if err := validateRecipeSpec(spec); err != nil {
+		return nil, err
+	}
+
+	riddle := &spec.RiddleSpec
+	tags := riddle.tags()
+	node, err := recipeSearch(riddle.space(), riddle.initial(), spec.Targets)
+	if err != nil {
+		return nil, &AppError{
+			Error:   err,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+	if node == nil {
+		return nil, &AppError{
+			Error:   fmt.Errorf("there is no solution to deliver %v with jugs with %v", spec.Targets, spec.Capacities),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return &RiddleResponse{
+		Operations: node.operations(),
+		TotalSteps: node.depth,
+		Levels:     node.state.levels(tags),
+		Optimal:    true,
+	}, nil
+}
+
+/*
+ recipeSearch runs a breadth-first search like search does, where a jug holding the next target can also deliver it,
+      which empties the jug. As the same state is expanded again for every amount of targets delivered, the water left
+      after delivering a target is reused for the next ones whenever that takes fewer steps than starting over.
+
+ *searchNode: contains the node where the last target is delivered, or nil if there is none
+ error: is not nil when there are too many states to search
+*/
+func recipeSearch(space *searchSpace, initial jugState, targets []int) (*searchNode, error) {
+	root := &recipeNode{node: &searchNode{state: initial}}
+	if len(targets) == 0 {
+		return root.node, nil
+	}
+
+	key := func(n *recipeNode) string {
+		return n.node.state.key() + "#" + strconv.Itoa(n.delivered)
+	}
+
+	leastDrawn := map[string]int{key(root): 0}
+	queue := []*recipeNode{root}
+	visits := 1
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		var nodes []*recipeNode
+		for _, next := range space.expand(current.node) {
+			nodes = append(nodes, &recipeNode{node: next, delivered: current.delivered})
+		}
+		for i, level := range current.node.state {
+			if level == targets[current.delivered] {
+				nodes = append(nodes, &recipeNode{
+					node:      deliver(current.node, i, space.tags[i]),
+					delivered: current.delivered + 1,
+				})
+			}
+		}
+
+		for _, next := range nodes {
+			nextKey := key(next)
+			if !space.improves(leastDrawn, nextKey, next.node.drawn) {
+				continue
+			}
+			leastDrawn[nextKey] = next.node.drawn
+			if visits++; visits > maxSearchStates {
+				return nil, errTooManyStates()
+			}
+
+			if next.delivered == len(targets) {
+				return next.node, nil
+			}
+			queue = append(queue, next)
+		}
+	}
+
+	return nil, nil
+}
+
+// deliver returns the node reached by handing off the water of jug
+func deliver(node *searchNode, jug int, tag string) *searchNode {
+	state := node.copyState()
+	state[jug] = 0
+	return &searchNode{
+		state:     state,
+		parent:    node,
+		operation: deliverOperation(tag, node.state[jug], node.depth+1),
+		depth:     node.depth + 1,
+		drawn:     node.drawn,
+	}
+}
+
+func validateRecipeSpec(spec *RecipeSpec) *AppError {
+	if len(spec.Targets) == 0 {
+		return &AppError{
+			Error:   errors.New("at least one target is required"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if spec.Z != 0 || spec.Total || spec.Goal != nil || spec.Costs != nil {
+		return &AppError{
+			Error:   errors.New("z, total, goal and costs can't be used in a recipe, as it measures its targets instead"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if err := validateRiddleSpec(&spec.RiddleSpec); err != nil {
+		return err
+	}
+
+	divisor := spec.divisor()
+	for _, target := range spec.Targets {
+		if target > maxOf(spec.Capacities...) {
+			return &AppError{
+				Error:   fmt.Errorf("can't measure %d if it's bigger than jugs for %v", target, spec.Capacities),
+				Message: "invalid parameters",
+				Code:    http.StatusBadRequest,
+			}
+		}
+		if target%divisor != 0 {
+			return &AppError{
+				Error:   fmt.Errorf("there is no solution to measure %d with jugs with %v", target, spec.Capacities),
+				Message: "invalid parameters",
+				Code:    http.StatusBadRequest,
+			}
+		}
+	}
+
+	return nil
+}
+
+func deliverOperation(jugTag string, amount, step int) Operation {
+	return Operation{
+		OperationType: operationTypeDeliver,
+		Jug:           aws.String(jugTag),
+		WaterAmount:   amount,
+		Description:   fmt.Sprintf("delivering %d from jug %s", amount, jugTag),
+		Step:          step,
+	}
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_RecipeRiddle(t *testing.T) {
+	type want struct {
+		output    *RiddleResponse
+		outputErr *AppError
+	}
+	tests := []struct {
+		name string
+		spec *RecipeSpec
+		want want
+	}{
+		{
+			name: "targets are missing",
+			spec: &RecipeSpec{
+				RiddleSpec: RiddleSpec{Capacities: []int{3, 5}},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("at least one target is required"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "z is used together with targets",
+			spec: &RecipeSpec{
+				RiddleSpec: RiddleSpec{Capacities: []int{3, 5}, Z: 4},
+				Targets:    []int{4},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("z, total, goal and costs can't be used in a recipe, as it measures its targets instead"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "target is bigger than jugs",
+			spec: &RecipeSpec{
+				RiddleSpec: RiddleSpec{Capacities: []int{3, 5}},
+				Targets:    []int{4, 6},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("can't measure %d if it's bigger than jugs for %v", 6, []int{3, 5}),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "gcd of every jug doesn't divide a target",
+			spec: &RecipeSpec{
+				RiddleSpec: RiddleSpec{Capacities: []int{2, 4}},
+				Targets:    []int{2, 3},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("there is no solution to measure %d with jugs with %v", 3, []int{2, 4}),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "restrictions leave the recipe without solution",
+			spec: &RecipeSpec{
+				RiddleSpec: RiddleSpec{
+					Capacities:   []int{3, 5},
+					Restrictions: &Restrictions{Operations: []OperationType{operationTypePour}},
+				},
+				Targets: []int{3, 2},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("there is no solution to deliver %v with jugs with %v", []int{3, 2}, []int{3, 5}),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "success reusing the water left by the previous target",
+			spec: &RecipeSpec{
+				RiddleSpec: RiddleSpec{Capacities: []int{3, 5}, Names: []string{xJugTag, yJugTag}},
+				Targets:    []int{2, 3},
+			},
+			want: want{
+				output: &RiddleResponse{
+					Operations: []Operation{
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String(yJugTag),
+							WaterAmount:   5,
+							Step:          1,
+							Description:   fmt.Sprintf("filling jug %s with 5 capacity", yJugTag),
+						},
+						{
+							OperationType:  operationTypePour,
+							JugOrigin:      aws.String(yJugTag),
+							JugDestination: aws.String(xJugTag),
+							WaterAmount:    3,
+							Step:           2,
+							Description:    fmt.Sprintf("pouring water from jug %s to %s", yJugTag, xJugTag),
+						},
+						{
+							OperationType: operationTypeDeliver,
+							Jug:           aws.String(yJugTag),
+							WaterAmount:   2,
+							Step:          3,
+							Description:   fmt.Sprintf("delivering 2 from jug %s", yJugTag),
+						},
+						{
+							OperationType: operationTypeDeliver,
+							Jug:           aws.String(xJugTag),
+							WaterAmount:   3,
+							Step:          4,
+							Description:   fmt.Sprintf("delivering 3 from jug %s", xJugTag),
+						},
+					},
+					TotalSteps: 4,
+					Levels:     map[string]int{xJugTag: 0, yJugTag: 0},
+					Optimal:    true,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &service{}
+			output, outputErr := svc.RecipeRiddle(tt.spec)
+
+			a := assert.New(t)
+			a.Equal(tt.want.output, output)
+			a.Equal(tt.want.outputErr, outputErr)
+		})
+	}
+}
+
+func TestService_RecipeRiddle_Delivers(t *testing.T) {
+	svc := &service{}
+	output, outputErr := svc.RecipeRiddle(&RecipeSpec{
+		RiddleSpec: RiddleSpec{Capacities: []int{3, 5}},
+		Targets:    []int{4, 2, 1},
+	})
+
+	a := assert.New(t)
+	a.Nil(outputErr)
+	a.Equal(15, output.TotalSteps)
+
+	var delivered []int
+	for _, operation := range output.Operations {
+		if operation.OperationType == operationTypeDeliver {
+			delivered = append(delivered, operation.WaterAmount)
+		}
+	}
+	a.Equal([]int{4, 2, 1}, delivered)
+	a.Equal(operationTypeDeliver, output.Operations[output.TotalSteps-1].OperationType)
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// Restrictions describes the operations a plan can't use
+type Restrictions struct {
+	// Operations contains the operation types that can't be used on any jug
+	Operations []OperationType `json:"operations,omitempty"`
+	// Jugs contains the operation types that can't be used on some jugs, by tag. Pours are restricted by their origin
+	Jugs map[string][]OperationType `json:"jugs,omitempty"`
+	// Pours contains the pours that can't be done between two jugs
+	Pours []Pour `json:"pours,omitempty"`
+}
+
+// Pour is a transfer of water from the jug tagged From to the jug tagged To
+type Pour struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// allows returns the check for the operations that are not restricted
+func (r *Restrictions) allows() func(Operation) bool {
+	operations := map[OperationType]bool{}
+	for _, operationType := range r.Operations {
+		operations[operationType] = true
+	}
+
+	jugs := map[string]bool{}
+	for tag, operationTypes := range r.Jugs {
+		for _, operationType := range operationTypes {
+			jugs[restrictionKey(operationType, tag)] = true
+		}
+	}
+
+	pours := map[Pour]bool{}
+	for _, pour := range r.Pours {
+		pours[pour] = true
+	}
+
+	return func(operation Operation) bool {
+		if operations[operation.OperationType] {
+			return false
+		}
+		if operation.OperationType != operationTypePour {
+			return !jugs[restrictionKey(operation.OperationType, aws.StringValue(operation.Jug))]
+		}
+
+		origin := aws.StringValue(operation.JugOrigin)
+		return !jugs[restrictionKey(operation.OperationType, origin)] &&
+			!pours[Pour{From: origin, To: aws.StringValue(operation.JugDestination)}]
+	}
+}
+
+func restrictionKey(operationType OperationType, tag string) string {
+	return fmt.Sprintf("%s/%s", operationType, tag)
+}
+
+func validateRestrictions(restrictions *Restrictions, tags []string) error {
+	known := map[string]bool{}
+	for _, tag := range tags {
+		known[tag] = true
+	}
+
+	for _, operationType := range restrictions.Operations {
+		if err := validateOperationType(operationType); err != nil {
+			return err
+		}
+	}
+
+	for tag, operationTypes := range restrictions.Jugs {
+		if !known[tag] {
+			return fmt.Errorf("unknown jug %s", tag)
+		}
+		for _, operationType := range operationTypes {
+			if err := validateOperationType(operationType); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, pour := range restrictions.Pours {
+		if !known[pour.From] {
+			return fmt.Errorf("unknown jug %s", pour.From)
+		}
+		if !known[pour.To] {
+			return fmt.Errorf("unknown jug %s", pour.To)
+		}
+		if pour.From == pour.To {
+			return fmt.Errorf("jug %s can't pour into itself", pour.From)
+		}
+	}
+	return nil
+}
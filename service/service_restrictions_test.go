@@ -0,0 +1,149 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_SolveRiddle_Restrictions(t *testing.T) {
+	type want struct {
+		totalSteps int
+		outputErr  *AppError
+	}
+	tests := []struct {
+		name         string
+		restrictions *Restrictions
+		want         want
+	}{
+		{
+			name:         "unknown operation",
+			restrictions: &Restrictions{Operations: []OperationType{"spill"}},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("unknown operation spill"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name:         "unknown jug",
+			restrictions: &Restrictions{Jugs: map[string][]OperationType{"w": {operationTypeEmpty}}},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("unknown jug w"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name:         "jug pouring into itself",
+			restrictions: &Restrictions{Pours: []Pour{{From: xJugTag, To: xJugTag}}},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("jug %s can't pour into itself", xJugTag),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name:         "no solution without emptying jugs",
+			restrictions: &Restrictions{Operations: []OperationType{operationTypeEmpty}},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("there is no solution with jugs with %v without the restricted operations", []int{3, 5}),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name:         "success without filling jug y",
+			restrictions: &Restrictions{Jugs: map[string][]OperationType{yJugTag: {operationTypeFill}}},
+			want: want{
+				totalSteps: 8,
+			},
+		},
+		{
+			name:         "success without pouring from y into x",
+			restrictions: &Restrictions{Pours: []Pour{{From: yJugTag, To: xJugTag}}},
+			want: want{
+				totalSteps: 8,
+			},
+		},
+		{
+			name:         "success without emptying jug y",
+			restrictions: &Restrictions{Jugs: map[string][]OperationType{yJugTag: {operationTypeEmpty}}},
+			want: want{
+				totalSteps: 6,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &service{}
+			output, outputErr := svc.SolveRiddle(&RiddleSpec{
+				Capacities:   []int{3, 5},
+				Names:        []string{xJugTag, yJugTag},
+				Z:            4,
+				Restrictions: tt.restrictions,
+			})
+
+			a := assert.New(t)
+			a.Equal(tt.want.outputErr, outputErr)
+			if tt.want.outputErr != nil {
+				return
+			}
+
+			a.Equal(tt.want.totalSteps, output.TotalSteps)
+			allows := tt.restrictions.allows()
+			for _, operation := range output.Operations {
+				a.True(allows(operation), "operation %s is restricted", operation.Description)
+			}
+		})
+	}
+}
+
+func TestService_SolveRiddle_RestrictionsWithoutAnySolution(t *testing.T) {
+	svc := &service{}
+	output, outputErr := svc.SolveRiddle(&RiddleSpec{
+		Capacities:   []int{2, 4},
+		Names:        []string{xJugTag, yJugTag},
+		Z:            3,
+		Restrictions: &Restrictions{Operations: []OperationType{operationTypeEmpty}},
+	})
+
+	// Not even jugs without restrictions measure 3, so they are not to blame
+	a := assert.New(t)
+	a.Nil(output)
+	a.Equal(&AppError{
+		Error:   fmt.Errorf("there is no solution to measure %d with jugs with %v", 3, []int{2, 4}),
+		Message: "invalid parameters",
+		Code:    http.StatusBadRequest,
+	}, outputErr)
+}
+
+func TestService_SolveRiddle_RestrictionsWithUnreachableGoal(t *testing.T) {
+	svc := &service{}
+	output, outputErr := svc.SolveRiddle(&RiddleSpec{
+		Capacities:   []int{3, 5},
+		Names:        []string{xJugTag, yJugTag},
+		Goal:         &RiddleGoal{Levels: map[string]int{xJugTag: 1, yJugTag: 1}},
+		Restrictions: &Restrictions{Operations: []OperationType{operationTypeEmpty}},
+	})
+
+	// Jugs without restrictions always leave one of them empty or full, so they can't reach the goal either
+	a := assert.New(t)
+	a.Nil(output)
+	a.Equal(&AppError{
+		Error:   fmt.Errorf("there is no solution to reach the goal with jugs with %v", []int{3, 5}),
+		Message: "invalid parameters",
+		Code:    http.StatusBadRequest,
+	}, outputErr)
+}
@@ -14,25 +14,44 @@ const (
 	operationTypeFill  OperationType = "fill"
 	operationTypeEmpty OperationType = "empty"
 	operationTypePour  OperationType = "pour"
+	// operationTypeTilt leaves exactly half the capacity of a jug, by tilting it until the water reaches the rim edge
+	operationTypeTilt OperationType = "tilt"
+	// operationTypeDeliver hands off the water of a jug once it holds a target of a recipe
+	operationTypeDeliver OperationType = "deliver"
 
 	xJugTag = "x"
 	yJugTag = "y"
+
+	// maxSearchCapacity is the most water both jugs can hold together when searching the shortest plan. Every state
+	// reached has a jug empty or full, so about twice as many states are visited at most
+	maxSearchCapacity = 100000
 )
 
 type Operation struct {
 	OperationType  `json:"operation,omitempty"`
-	Jug            *string `json:"jug,omitempty"`
-	JugOrigin      *string `json:"jug_origin,omitempty"`
-	JugDestination *string `json:"jug_destination,omitempty"`
-	WaterAmount    int     `json:"amount,omitempty"`
-	Step           int     `json:"step,omitempty"`
-	Description    string  `json:"description,omitempty"`
+	Jug            *string           `json:"jug,omitempty"`
+	JugOrigin      *string           `json:"jug_origin,omitempty"`
+	JugDestination *string           `json:"jug_destination,omitempty"`
+	WaterAmount    int               `json:"amount,omitempty"`
+	Step           int               `json:"step,omitempty"`
+	Description    string            `json:"description,omitempty"`
+	Cost           float64           `json:"cost,omitempty"`
+	Composition    map[string]string `json:"composition,omitempty"`
+	// Mark is the graduation mark a fill or pour stops at, instead of a full or empty jug
+	Mark int `json:"mark,omitempty"`
 }
 
 type RiddleResponse struct {
-	Operations []Operation `json:"operations,omitempty"`
-	Jug        string      `json:"jug,omitempty"`
-	TotalSteps int         `json:"total_steps,omitempty"`
+	Operations []Operation      `json:"operations,omitempty"`
+	Blocks     []OperationBlock `json:"blocks,omitempty"`
+	Jug        string           `json:"jug,omitempty"`
+	TotalSteps int              `json:"total_steps,omitempty"`
+	Levels     map[string]int   `json:"levels,omitempty"`
+	// Mixtures contains the amount of every liquid in every jug, by tag, when jugs hold a mixture of liquids
+	Mixtures   map[string]map[string]string `json:"mixtures,omitempty"`
+	TotalCost  *float64                     `json:"total_cost,omitempty"`
+	Optimal    bool                         `json:"optimal,omitempty"`
+	NextCursor string                       `json:"next_cursor,omitempty"`
 }
 
 func (s *service) Riddle(x, y, z int) (*RiddleResponse, *AppError) {
@@ -48,7 +67,48 @@ func (s *service) Riddle(x, y, z int) (*RiddleResponse, *AppError) {
 	}, nil
 }
 
+func (s *service) OptimalRiddle(x, y, z int) (*RiddleResponse, *AppError) {
+	if err := validateRiddle(x, y, z); err != nil {
+		return nil, err
+	}
+
+	if err := validateSearchCapacity(x, y); err != nil {
+		return nil, err
+	}
+
+	tags := []string{xJugTag, yJugTag}
+	space := &searchSpace{capacities: []int{x, y}, tags: tags}
+	node, searchErr := search(space, jugState{0, 0}, func(state jugState) bool {
+		return jugWith(state, z) >= 0
+	})
+	if searchErr != nil {
+		return nil, &AppError{
+			Error:   searchErr,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+	if node == nil {
+		return nil, &AppError{
+			Error:   fmt.Errorf("there is no solution to measure %d with jugs with %d and %d", z, x, y),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return &RiddleResponse{
+		Operations: node.operations(),
+		Jug:        tags[jugWith(node.state, z)],
+		TotalSteps: node.depth,
+		Optimal:    true,
+	}, nil
+}
+
 func (s *service) getOperations(x, y, z int) ([]Operation, string, *AppError) {
+	if err := validateRiddle(x, y, z); err != nil {
+		return nil, "", err
+	}
+
 	smallerJug := x
 	biggerJug := y
 
@@ -64,25 +124,6 @@ func (s *service) getOperations(x, y, z int) ([]Operation, string, *AppError) {
 		biggerJugTag = xJugTag
 	}
 
-	if z > biggerJug {
-		return nil, "", &AppError{
-			Error:   fmt.Errorf("can't measure %d if it's bigger than jugs for %d and %d", z, smallerJug, biggerJug),
-			Message: "invalid parameters",
-			Code:    http.StatusBadRequest,
-		}
-	}
-
-	// If gcd of smaller jug and bigger jug does not divide z, then solution is not possible
-	calculatedGcd := gcd(smallerJug, biggerJug)
-	if (z % calculatedGcd) != 0 {
-		return nil, "", &AppError{
-			Error: fmt.Errorf("there is no solution to measure %d with jugs with %d and %d", z, smallerJug, biggerJug),
-			Message: "invalid parameters",
-			Code:  http.StatusBadRequest,
-		}
-	}
-
-
 	// Test two possible scenarios
 	wg := sync.WaitGroup{}
 	wg.Add(2)
@@ -114,6 +155,45 @@ func (s *service) getOperations(x, y, z int) ([]Operation, string, *AppError) {
 	return secondSolutionOperations, secondSolutionJug, nil
 }
 
+// validateRiddle checks that z can be measured with jugs of x and y capacity
+func validateRiddle(x, y, z int) *AppError {
+	smallerJug := min(x, y)
+	biggerJug := max(x, y)
+
+	if z > biggerJug {
+		return &AppError{
+			Error:   fmt.Errorf("can't measure %d if it's bigger than jugs for %d and %d", z, smallerJug, biggerJug),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	// If gcd of smaller jug and bigger jug does not divide z, then solution is not possible
+	calculatedGcd := gcd(smallerJug, biggerJug)
+	if (z % calculatedGcd) != 0 {
+		return &AppError{
+			Error:   fmt.Errorf("there is no solution to measure %d with jugs with %d and %d", z, smallerJug, biggerJug),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return nil
+}
+
+// validateSearchCapacity checks that jugs with x and y are small enough to search every state they can reach
+func validateSearchCapacity(x, y int) *AppError {
+	if x+y > maxSearchCapacity {
+		return &AppError{
+			Error:   fmt.Errorf("jugs are too big to search the shortest plan, they can't hold more than %d together",
+				maxSearchCapacity),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return nil
+}
+
 /*
  pour returns all the operations required to measure z amount of water by constantly pouring water from jug with name
       jug1Tag into jug with name jug2Tag
@@ -124,19 +204,35 @@ func (s *service) getOperations(x, y, z int) ([]Operation, string, *AppError) {
 
 func (s *service) pour(jug1Cap int, jug1Tag string, jug2Cap int, jug2Tag string, z int) (ops []Operation,
 	jugTag string) {
-	var operations []Operation
+	jugTag, _ = s.pourEach(jug1Cap, jug1Tag, jug2Cap, jug2Tag, z, func(operation Operation) error {
+		ops = append(ops, operation)
+		return nil
+	})
+	return
+}
 
+/*
+ pourEach generates the same operations as pour, one at a time, passing each of them to emit as soon as it's known.
+      Generation stops as soon as emit fails.
+
+ string: contains the tag of the jug with the amount of water requested
+ error:  contains the error returned by emit
+*/
+
+func (s *service) pourEach(jug1Cap int, jug1Tag string, jug2Cap int, jug2Tag string, z int,
+	emit func(Operation) error) (jugTag string, err error) {
 	jug1 := jug1Cap
 	jug2 := 0
 
 	step := 1
-	operations = append(operations, Operation{
-		OperationType: operationTypeFill,
-		Jug:           aws.String(jug1Tag),
-		WaterAmount:   jug1Cap,
-		Description:   fmt.Sprintf("filling jug %s with %d capacity", jug1Tag, jug1Cap),
-		Step:          step,
-	})
+	if err := emit(fillOperation(jug1Tag, jug1Cap, jug1Cap, step)); err != nil {
+		return "", err
+	}
+
+	// Filling the first jug may be enough
+	if jug1 == z {
+		jugTag = jug1Tag
+	}
 
 	// Break the loop when either of the two jugs has z water
 	for jug1 != z && jug2 != z {
@@ -148,14 +244,9 @@ func (s *service) pour(jug1Cap int, jug1Tag string, jug2Cap int, jug2Tag string,
 		jug1 -= temp
 
 		step++
-		operations = append(operations, Operation{
-			OperationType:  operationTypePour,
-			JugOrigin:      aws.String(jug1Tag),
-			JugDestination: aws.String(jug2Tag),
-			WaterAmount:    temp,
-			Description:    fmt.Sprintf("pouring water from jug %s to %s", jug1Tag, jug2Tag),
-			Step:           step,
-		})
+		if err := emit(pourOperation(jug1Tag, jug2Tag, temp, step)); err != nil {
+			return "", err
+		}
 
 		if jug1 == z {
 			jugTag = jug1Tag
@@ -171,29 +262,61 @@ func (s *service) pour(jug1Cap int, jug1Tag string, jug2Cap int, jug2Tag string,
 		if jug1 == 0 {
 			jug1 = jug1Cap
 			step++
-			operations = append(operations, Operation{
-				OperationType: operationTypeFill,
-				Jug:           aws.String(jug1Tag),
-				WaterAmount:   jug1Cap,
-				Description:   fmt.Sprintf("filling jug %s with %d capacity", jug1Tag, jug1Cap),
-				Step:          step,
-			})
+			if err := emit(fillOperation(jug1Tag, jug1Cap, jug1Cap, step)); err != nil {
+				return "", err
+			}
 		}
 
 		// If second jug becomes full, empty it
 		if jug2 == jug2Cap {
 			jug2 = 0
 			step++
-			operations = append(operations, Operation{
-				OperationType: operationTypeEmpty,
-				Jug:           aws.String(jug2Tag),
-				WaterAmount:   jug2Cap,
-				Description:   fmt.Sprintf("emptying jug %s with %d capacity", jug2Tag, jug2Cap),
-				Step:          step,
-			})
+			if err := emit(emptyOperation(jug2Tag, jug2Cap, jug2Cap, step)); err != nil {
+				return "", err
+			}
 		}
 	}
 
-	ops = operations
-	return
+	return jugTag, nil
+}
+
+func fillOperation(jugTag string, capacity, amount, step int) Operation {
+	return Operation{
+		OperationType: operationTypeFill,
+		Jug:           aws.String(jugTag),
+		WaterAmount:   amount,
+		Description:   fmt.Sprintf("filling jug %s with %d capacity", jugTag, capacity),
+		Step:          step,
+	}
+}
+
+func emptyOperation(jugTag string, capacity, amount, step int) Operation {
+	return Operation{
+		OperationType: operationTypeEmpty,
+		Jug:           aws.String(jugTag),
+		WaterAmount:   amount,
+		Description:   fmt.Sprintf("emptying jug %s with %d capacity", jugTag, capacity),
+		Step:          step,
+	}
+}
+
+func tiltOperation(jugTag string, capacity, amount, step int) Operation {
+	return Operation{
+		OperationType: operationTypeTilt,
+		Jug:           aws.String(jugTag),
+		WaterAmount:   amount,
+		Description:   fmt.Sprintf("tilting jug %s with %d capacity to half", jugTag, capacity),
+		Step:          step,
+	}
+}
+
+func pourOperation(originTag, destinationTag string, amount, step int) Operation {
+	return Operation{
+		OperationType:  operationTypePour,
+		JugOrigin:      aws.String(originTag),
+		JugDestination: aws.String(destinationTag),
+		WaterAmount:    amount,
+		Description:    fmt.Sprintf("pouring water from jug %s to %s", originTag, destinationTag),
+		Step:           step,
+	}
 }
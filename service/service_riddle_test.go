@@ -176,6 +176,29 @@ func TestService_Riddle(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "success with x = 3, y = 5 and z = 3, where filling the first jug is enough",
+			args: args{
+				x: 3,
+				y: 5,
+				z: 3,
+			},
+			want: want{
+				output: &RiddleResponse{
+					Operations: []Operation{
+						{
+							OperationType: operationTypeFill,
+							Jug: aws.String(xJugTag),
+							WaterAmount: 3,
+							Step: 1,
+							Description: fmt.Sprintf("filling jug %s with 3 capacity", xJugTag),
+						},
+					},
+					Jug: xJugTag,
+					TotalSteps: 1,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -195,3 +218,163 @@ func TestService_Riddle(t *testing.T) {
 		})
 	}
 }
+
+func TestService_OptimalRiddle(t *testing.T) {
+	type args struct {
+		x int
+		y int
+		z int
+	}
+	type want struct {
+		output    *RiddleResponse
+		outputErr *AppError
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "z is bigger than x and y",
+			args: args{
+				x: 1,
+				y: 2,
+				z: 3,
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("can't measure %d if it's bigger than jugs for %d and %d", 3, 1, 2),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "gcd of smaller jug and bigger jug doesn't divide z, i.e. x and y are multiples and z is not",
+			args: args{
+				x: 4,
+				y: 2,
+				z: 3,
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("there is no solution to measure %d with jugs with %d and %d", 3, 2, 4),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "x and y are too big to search",
+			args: args{
+				x: 60001,
+				y: 50000,
+				z: 1,
+			},
+			want: want{
+				outputErr: &AppError{
+					Error: fmt.Errorf("jugs are too big to search the shortest plan, they can't hold more than %d together",
+						maxSearchCapacity),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "success with x = 1, y = 2 and z = 1",
+			args: args{
+				x: 1,
+				y: 2,
+				z: 1,
+			},
+			want: want{
+				output: &RiddleResponse{
+					Operations: []Operation{
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String(xJugTag),
+							WaterAmount:   1,
+							Step:          1,
+							Description:   fmt.Sprintf("filling jug %s with 1 capacity", xJugTag),
+						},
+					},
+					Jug:        xJugTag,
+					TotalSteps: 1,
+					Optimal:    true,
+				},
+			},
+		},
+		{
+			name: "success with x = 3, y = 5 and z = 4",
+			args: args{
+				x: 3,
+				y: 5,
+				z: 4,
+			},
+			want: want{
+				output: &RiddleResponse{
+					Operations: []Operation{
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String(yJugTag),
+							WaterAmount:   5,
+							Step:          1,
+							Description:   fmt.Sprintf("filling jug %s with 5 capacity", yJugTag),
+						},
+						{
+							OperationType:  operationTypePour,
+							JugOrigin:      aws.String(yJugTag),
+							JugDestination: aws.String(xJugTag),
+							WaterAmount:    3,
+							Step:           2,
+							Description:    fmt.Sprintf("pouring water from jug %s to %s", yJugTag, xJugTag),
+						},
+						{
+							OperationType: operationTypeEmpty,
+							Jug:           aws.String(xJugTag),
+							WaterAmount:   3,
+							Step:          3,
+							Description:   fmt.Sprintf("emptying jug %s with 3 capacity", xJugTag),
+						},
+						{
+							OperationType:  operationTypePour,
+							JugOrigin:      aws.String(yJugTag),
+							JugDestination: aws.String(xJugTag),
+							WaterAmount:    2,
+							Step:           4,
+							Description:    fmt.Sprintf("pouring water from jug %s to %s", yJugTag, xJugTag),
+						},
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String(yJugTag),
+							WaterAmount:   5,
+							Step:          5,
+							Description:   fmt.Sprintf("filling jug %s with 5 capacity", yJugTag),
+						},
+						{
+							OperationType:  operationTypePour,
+							JugOrigin:      aws.String(yJugTag),
+							JugDestination: aws.String(xJugTag),
+							WaterAmount:    1,
+							Step:           6,
+							Description:    fmt.Sprintf("pouring water from jug %s to %s", yJugTag, xJugTag),
+						},
+					},
+					Jug:        yJugTag,
+					TotalSteps: 6,
+					Optimal:    true,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &service{}
+			output, outputErr := svc.OptimalRiddle(tt.args.x, tt.args.y, tt.args.z)
+
+			a := assert.New(t)
+			a.Equal(tt.want.output, output)
+			a.Equal(tt.want.outputErr, outputErr)
+		})
+	}
+}
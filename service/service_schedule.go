@@ -0,0 +1,399 @@
+package service
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+const (
+	maxScheduleWorkers = 10
+	// maxScheduleLabels is the most partial schedules the search keeps before giving up
+	maxScheduleLabels = 200000
+	// scheduleEpsilon is the margin times are compared with, as they add up fractions of water
+	scheduleEpsilon = 1e-9
+)
+
+// ScheduleSpec describes a riddle whose plan is scheduled in time, with several workers handling jugs at once
+type ScheduleSpec struct {
+	RiddleSpec
+	// Rates contains the units of water per unit of time that flow through the tap, between jugs and to the drain
+	Rates FlowRates `json:"rates"`
+	// Workers is the number of people that can perform operations at the same time. There is one when it's missing
+	Workers int `json:"workers,omitempty"`
+}
+
+type FlowRates struct {
+	Tap   float64 `json:"tap"`
+	Pour  float64 `json:"pour"`
+	Drain float64 `json:"drain"`
+}
+
+// duration returns the time the operation takes, as the water it moves flows at the rate of the tap, between jugs or
+// to the drain. Tilted jugs spill water out, just like emptied ones
+func (r FlowRates) duration(operation Operation) float64 {
+	amount := float64(operation.WaterAmount)
+	switch operation.OperationType {
+	case operationTypeFill:
+		return amount / r.Tap
+	case operationTypePour:
+		return amount / r.Pour
+	default:
+		return amount / r.Drain
+	}
+}
+
+// ScheduledOperation is an operation of a plan performed by a worker from start to end
+type ScheduledOperation struct {
+	Operation
+	Worker int     `json:"worker"`
+	Start  float64 `json:"start"`
+	End    float64 `json:"end"`
+}
+
+type ScheduleResponse struct {
+	Timeline   []ScheduledOperation `json:"timeline"`
+	Jug        string               `json:"jug,omitempty"`
+	TotalSteps int                  `json:"total_steps"`
+	Levels     map[string]int       `json:"levels,omitempty"`
+	// TotalTime is the wall-clock time from the start of the first operation to the end of the last one
+	TotalTime float64 `json:"total_time"`
+}
+
+func (s *service) ScheduleRiddle(spec *ScheduleSpec) (*ScheduleResponse, *AppError) {
+	if err := validateScheduleSpec(spec); err != nil {
+		return nil, err
+	}
+
+	workers := spec.Workers
+	if workers == 0 {
+		workers = 1
+	}
+
+	riddle := &spec.RiddleSpec
+	tags := riddle.tags()
+	space := riddle.space()
+
+	// Finding out that there is no plan at all is much quicker without keeping track of time
+	node, err := search(space, riddle.initial(), riddle.goal())
+	if err == nil && node == nil {
+		return nil, riddle.unsolvable()
+	}
+	var label *scheduleLabel
+	if err == nil {
+		label, err = scheduleSearch(space, riddle.initial(), riddle.goal(), spec.Rates, workers)
+	}
+	if err != nil {
+		return nil, &AppError{
+			Error:   err,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	// Choosing among the earliest workers the one idle the shortest, as schedule does, never ends later than the label
+	operations := label.node.operations()
+	timeline, totalTime := schedule(operations, tags, spec.Rates, workers)
+	return &ScheduleResponse{
+		Timeline:   timeline,
+		Jug:        riddle.jug(label.node.state, tags),
+		TotalSteps: len(operations),
+		Levels:     label.node.state.levels(tags),
+		TotalTime:  totalTime,
+	}, nil
+}
+
+/*
+ scheduleLabel is a plan reaching node, scheduled by starting every operation as soon as its jugs and a worker are
+      free. Every schedule performs its operations in some order, and starting each of them on the worker that is free
+      the earliest never starts it later, so the quickest schedule is the quickest label of some plan. What is left of
+      a plan only depends on the state it reaches, when its jugs and tap are ready and when its workers are free.
+*/
+type scheduleLabel struct {
+	node *searchNode
+	// ready contains the time every jug, and the tap, is done with its last operation
+	ready []float64
+	// free contains the time every worker is done with its last operation, from the earliest to the latest
+	free []float64
+	// dominated tells whether another label reaching the same state is ready and free no later than this one
+	dominated bool
+	seq       int
+}
+
+// time returns the time the last operation of the label ends
+func (l *scheduleLabel) time() float64 {
+	return l.free[len(l.free)-1]
+}
+
+/*
+ scheduleSearch runs a best-first search over labels, from the quickest one, extending them with every possible
+      operation. As operations never end earlier than the previous ones, the first label accepted by goal is the
+      quickest one. Labels whose jugs, tap and workers are all ready later than those of another label reaching the
+      same state are left out, as the other one can be extended in the same way without ending later.
+
+ *scheduleLabel: contains the label accepted by goal, or nil if there is none
+ error: is not nil when there are too many labels to search
+*/
+func scheduleSearch(space *searchSpace, initial jugState, goal func(jugState) bool, rates FlowRates,
+	workers int) (*scheduleLabel, error) {
+	indexes := map[string]int{}
+	for i, tag := range space.tags {
+		indexes[tag] = i
+	}
+	tap := len(space.tags)
+
+	root := &scheduleLabel{
+		node:  &searchNode{state: initial},
+		ready: make([]float64, len(space.tags)+1),
+		free:  make([]float64, workers),
+	}
+	kept := map[string][]*scheduleLabel{initial.key(): {root}}
+	supplied := space.supply != nil
+	queue := &labelQueue{labels: []*scheduleLabel{root}}
+	seq := 1
+
+	for queue.Len() > 0 {
+		label := heap.Pop(queue).(*scheduleLabel)
+		if label.dominated {
+			continue
+		}
+		if goal(label.node.state) {
+			return label, nil
+		}
+
+		for _, node := range space.expand(label.node) {
+			next := label.extend(node, operationResources(node.operation, indexes, tap), rates.duration(node.operation))
+			key := node.state.key()
+			if anyDominates(kept[key], next, supplied) {
+				continue
+			}
+
+			var labels []*scheduleLabel
+			for _, other := range kept[key] {
+				if next.dominates(other, supplied) {
+					other.dominated = true
+				} else {
+					labels = append(labels, other)
+				}
+			}
+			kept[key] = append(labels, next)
+
+			if seq++; seq > maxScheduleLabels {
+				return nil, fmt.Errorf("there are more than %d partial schedules to search, try fewer workers",
+					maxScheduleLabels)
+			}
+			next.seq = seq
+			heap.Push(queue, next)
+		}
+	}
+
+	return nil, nil
+}
+
+// extend returns the label reached by performing the operation of node on resources, on the worker free the earliest
+func (l *scheduleLabel) extend(node *searchNode, resources []int, duration float64) *scheduleLabel {
+	ready := append([]float64(nil), l.ready...)
+	free := append([]float64(nil), l.free...)
+
+	start := free[0]
+	for _, resource := range resources {
+		start = math.Max(start, ready[resource])
+	}
+	end := start + duration
+	for _, resource := range resources {
+		ready[resource] = end
+	}
+
+	// The worker keeps its place among the rest, from the earliest free to the latest
+	free[0] = end
+	for w := 1; w < len(free) && free[w] < free[w-1]; w++ {
+		free[w], free[w-1] = free[w-1], free[w]
+	}
+
+	return &scheduleLabel{node: node, ready: ready, free: free}
+}
+
+// dominates tells whether the jugs, tap and workers of l are ready no later than those of other, and whether it has
+// drawn no more water when supplied
+func (l *scheduleLabel) dominates(other *scheduleLabel, supplied bool) bool {
+	if supplied && l.node.drawn > other.node.drawn {
+		return false
+	}
+	for i := range l.ready {
+		if l.ready[i] > other.ready[i]+scheduleEpsilon {
+			return false
+		}
+	}
+	for w := range l.free {
+		if l.free[w] > other.free[w]+scheduleEpsilon {
+			return false
+		}
+	}
+	return true
+}
+
+func anyDominates(labels []*scheduleLabel, label *scheduleLabel, supplied bool) bool {
+	for _, other := range labels {
+		if other.dominates(label, supplied) {
+			return true
+		}
+	}
+	return false
+}
+
+// labelQueue is a priority queue of labels ordered by time, then by depth, then by insertion
+type labelQueue struct {
+	labels []*scheduleLabel
+}
+
+func (q *labelQueue) Len() int { return len(q.labels) }
+
+func (q *labelQueue) Less(i, j int) bool {
+	a, b := q.labels[i], q.labels[j]
+	if a.time() != b.time() {
+		return a.time() < b.time()
+	}
+	if a.node.depth != b.node.depth {
+		return a.node.depth < b.node.depth
+	}
+	return a.seq < b.seq
+}
+
+func (q *labelQueue) Swap(i, j int) { q.labels[i], q.labels[j] = q.labels[j], q.labels[i] }
+
+func (q *labelQueue) Push(x interface{}) {
+	q.labels = append(q.labels, x.(*scheduleLabel))
+}
+
+func (q *labelQueue) Pop() interface{} {
+	last := len(q.labels) - 1
+	label := q.labels[last]
+	q.labels = q.labels[:last]
+	return label
+}
+
+// operationResources returns the jugs the operation works on, and the tap for fills, by index
+func operationResources(operation Operation, indexes map[string]int, tap int) []int {
+	switch operation.OperationType {
+	case operationTypeFill:
+		return []int{indexes[aws.StringValue(operation.Jug)], tap}
+	case operationTypePour:
+		return []int{indexes[aws.StringValue(operation.JugOrigin)], indexes[aws.StringValue(operation.JugDestination)]}
+	default:
+		return []int{indexes[aws.StringValue(operation.Jug)]}
+	}
+}
+
+/*
+ schedule assigns every operation to a worker that is free the earliest, starting it as soon as the previous
+      operations on the same jugs are over. Fills also wait for the previous fill, as there is a single tap. Operations
+      on different jugs don't change each other's levels, so the plan reaches the same state at the end.
+
+ []ScheduledOperation: contains the operations in the order of the plan, with their worker, start and end
+ float64: is the time the last operation ends
+*/
+func schedule(operations []Operation, tags []string, rates FlowRates, workers int) ([]ScheduledOperation, float64) {
+	indexes := map[string]int{}
+	for i, tag := range tags {
+		indexes[tag] = i
+	}
+	tap := len(tags)
+
+	// jugsReady contains the time every jug, and the tap, is done with its last operation
+	jugsReady := make([]float64, len(tags)+1)
+	free := make([]float64, workers)
+	timeline := make([]ScheduledOperation, len(operations))
+	totalTime := 0.0
+
+	for k, operation := range operations {
+		resources := operationResources(operation, indexes, tap)
+		ready := 0.0
+		for _, resource := range resources {
+			ready = math.Max(ready, jugsReady[resource])
+		}
+
+		// Among the workers that can start the earliest, the one that has been idle the shortest keeps working, so
+		// the rest stay available
+		worker := 0
+		for w := range free {
+			start, best := math.Max(free[w], ready), math.Max(free[worker], ready)
+			if start < best || start == best && free[w] > free[worker] {
+				worker = w
+			}
+		}
+		start := math.Max(free[worker], ready)
+		end := start + rates.duration(operation)
+
+		free[worker] = end
+		for _, resource := range resources {
+			jugsReady[resource] = end
+		}
+		totalTime = math.Max(totalTime, end)
+
+		operation.Cost = 0
+		timeline[k] = ScheduledOperation{
+			Operation: operation,
+			Worker:    worker + 1,
+			Start:     start,
+			End:       end,
+		}
+	}
+
+	return timeline, totalTime
+}
+
+func validateScheduleSpec(spec *ScheduleSpec) *AppError {
+	if err := validateRiddleSpec(&spec.RiddleSpec); err != nil {
+		return err
+	}
+
+	if spec.Costs != nil {
+		return &AppError{
+			Error:   errors.New("costs can't be used when scheduling plans by time"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if spec.Rates.Tap <= 0 || spec.Rates.Pour <= 0 || spec.Rates.Drain <= 0 {
+		return &AppError{
+			Error:   errors.New("tap, pour and drain rates must be positive"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	// Plans never take more steps than partial schedules are kept, and no step moves more water than the biggest jug
+	longest := float64(maxOf(spec.Capacities...)) * maxScheduleLabels
+	for _, rate := range []float64{spec.Rates.Tap, spec.Rates.Pour, spec.Rates.Drain} {
+		if math.IsInf(longest/rate, 0) {
+			return &AppError{
+				Error:   errors.New("tap, pour and drain rates are too small to time the plan"),
+				Message: "invalid parameters",
+				Code:    http.StatusBadRequest,
+			}
+		}
+	}
+
+	if spec.Workers < 0 {
+		return &AppError{
+			Error:   errors.New("workers can't be negative"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if spec.Workers > maxScheduleWorkers {
+		return &AppError{
+			Error:   fmt.Errorf("workers can't be more than %d", maxScheduleWorkers),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return nil
+}
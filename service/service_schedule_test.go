@@ -0,0 +1,351 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_ScheduleRiddle(t *testing.T) {
+	type want struct {
+		output    *ScheduleResponse
+		outputErr *AppError
+	}
+	tests := []struct {
+		name string
+		spec *ScheduleSpec
+		want want
+	}{
+		{
+			name: "rates are missing",
+			spec: &ScheduleSpec{
+				RiddleSpec: RiddleSpec{Capacities: []int{3, 5}, Z: 4},
+				Rates:      FlowRates{Tap: 1, Pour: 2},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("tap, pour and drain rates must be positive"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "rates are too small",
+			spec: &ScheduleSpec{
+				RiddleSpec: RiddleSpec{Capacities: []int{3, 5}, Z: 4},
+				Rates:      FlowRates{Tap: 1, Pour: 1e-320, Drain: 1},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("tap, pour and drain rates are too small to time the plan"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "workers are negative",
+			spec: &ScheduleSpec{
+				RiddleSpec: RiddleSpec{Capacities: []int{3, 5}, Z: 4},
+				Rates:      FlowRates{Tap: 1, Pour: 2, Drain: 2},
+				Workers:    -1,
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("workers can't be negative"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "too many workers",
+			spec: &ScheduleSpec{
+				RiddleSpec: RiddleSpec{Capacities: []int{3, 5}, Names: []string{xJugTag, yJugTag}, Z: 4},
+				Rates:      FlowRates{Tap: 1, Pour: 2, Drain: 2},
+				Workers:    11,
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("workers can't be more than 10"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "costs are provided",
+			spec: &ScheduleSpec{
+				RiddleSpec: RiddleSpec{
+					Capacities: []int{3, 5},
+					Z:          4,
+					Costs:      map[OperationType]OperationCost{operationTypeFill: {Base: 1}},
+				},
+				Rates: FlowRates{Tap: 1, Pour: 2, Drain: 2},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("costs can't be used when scheduling plans by time"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "there is no solution",
+			spec: &ScheduleSpec{
+				RiddleSpec: RiddleSpec{Capacities: []int{2, 4}, Z: 3},
+				Rates:      FlowRates{Tap: 1, Pour: 2, Drain: 2},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("there is no solution to measure %d with jugs with %v", 3, []int{2, 4}),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "success with a single worker",
+			spec: &ScheduleSpec{
+				RiddleSpec: RiddleSpec{Capacities: []int{2, 3, 7}, Names: []string{xJugTag, yJugTag, "w"}, Z: 5},
+				Rates:      FlowRates{Tap: 1, Pour: 2, Drain: 2},
+			},
+			want: want{
+				output: &ScheduleResponse{
+					Timeline: []ScheduledOperation{
+						{
+							Operation: Operation{
+								OperationType: operationTypeFill,
+								Jug:           aws.String(xJugTag),
+								WaterAmount:   2,
+								Step:          1,
+								Description:   fmt.Sprintf("filling jug %s with 2 capacity", xJugTag),
+							},
+							Worker: 1,
+							Start:  0,
+							End:    2,
+						},
+						{
+							Operation: Operation{
+								OperationType:  operationTypePour,
+								JugOrigin:      aws.String(xJugTag),
+								JugDestination: aws.String("w"),
+								WaterAmount:    2,
+								Step:           2,
+								Description:    fmt.Sprintf("pouring water from jug %s to w", xJugTag),
+							},
+							Worker: 1,
+							Start:  2,
+							End:    3,
+						},
+						{
+							Operation: Operation{
+								OperationType: operationTypeFill,
+								Jug:           aws.String(yJugTag),
+								WaterAmount:   3,
+								Step:          3,
+								Description:   fmt.Sprintf("filling jug %s with 3 capacity", yJugTag),
+							},
+							Worker: 1,
+							Start:  3,
+							End:    6,
+						},
+						{
+							Operation: Operation{
+								OperationType:  operationTypePour,
+								JugOrigin:      aws.String(yJugTag),
+								JugDestination: aws.String("w"),
+								WaterAmount:    3,
+								Step:           4,
+								Description:    fmt.Sprintf("pouring water from jug %s to w", yJugTag),
+							},
+							Worker: 1,
+							Start:  6,
+							End:    7.5,
+						},
+					},
+					Jug:        "w",
+					TotalSteps: 4,
+					Levels:     map[string]int{xJugTag: 0, yJugTag: 0, "w": 5},
+					TotalTime:  7.5,
+				},
+			},
+		},
+		{
+			name: "success with two workers pouring while filling",
+			spec: &ScheduleSpec{
+				RiddleSpec: RiddleSpec{Capacities: []int{2, 3, 7}, Names: []string{xJugTag, yJugTag, "w"}, Z: 5},
+				Rates:      FlowRates{Tap: 1, Pour: 2, Drain: 2},
+				Workers:    2,
+			},
+			want: want{
+				output: &ScheduleResponse{
+					Timeline: []ScheduledOperation{
+						{
+							Operation: Operation{
+								OperationType: operationTypeFill,
+								Jug:           aws.String(yJugTag),
+								WaterAmount:   3,
+								Step:          1,
+								Description:   fmt.Sprintf("filling jug %s with 3 capacity", yJugTag),
+							},
+							Worker: 1,
+							Start:  0,
+							End:    3,
+						},
+						{
+							Operation: Operation{
+								OperationType:  operationTypePour,
+								JugOrigin:      aws.String(yJugTag),
+								JugDestination: aws.String("w"),
+								WaterAmount:    3,
+								Step:           2,
+								Description:    fmt.Sprintf("pouring water from jug %s to w", yJugTag),
+							},
+							Worker: 1,
+							Start:  3,
+							End:    4.5,
+						},
+						{
+							Operation: Operation{
+								OperationType: operationTypeFill,
+								Jug:           aws.String(xJugTag),
+								WaterAmount:   2,
+								Step:          3,
+								Description:   fmt.Sprintf("filling jug %s with 2 capacity", xJugTag),
+							},
+							Worker: 2,
+							Start:  3,
+							End:    5,
+						},
+						{
+							Operation: Operation{
+								OperationType:  operationTypePour,
+								JugOrigin:      aws.String(xJugTag),
+								JugDestination: aws.String("w"),
+								WaterAmount:    2,
+								Step:           4,
+								Description:    fmt.Sprintf("pouring water from jug %s to w", xJugTag),
+							},
+							Worker: 2,
+							Start:  5,
+							End:    6,
+						},
+					},
+					Jug:        "w",
+					TotalSteps: 4,
+					Levels:     map[string]int{xJugTag: 0, yJugTag: 0, "w": 5},
+					TotalTime:  6,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &service{}
+			output, outputErr := svc.ScheduleRiddle(tt.spec)
+
+			a := assert.New(t)
+			a.Equal(tt.want.output, output)
+			a.Equal(tt.want.outputErr, outputErr)
+		})
+	}
+}
+
+func TestService_ScheduleRiddle_Quickest(t *testing.T) {
+	tests := []struct {
+		name      string
+		rates     FlowRates
+		totalTime float64
+	}{
+		{
+			name:      "success with the same rates",
+			rates:     FlowRates{Tap: 1, Pour: 1, Drain: 1},
+			totalTime: 8,
+		},
+		{
+			name:      "success with a slow tap",
+			rates:     FlowRates{Tap: 1, Pour: 5, Drain: 5},
+			totalTime: 5.4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &service{}
+			output, outputErr := svc.ScheduleRiddle(&ScheduleSpec{
+				RiddleSpec: RiddleSpec{Capacities: []int{2, 3, 7}, Names: []string{xJugTag, yJugTag, "w"}, Z: 5},
+				Rates:      tt.rates,
+				Workers:    2,
+			})
+
+			a := assert.New(t)
+			a.Nil(outputErr)
+			a.InDelta(tt.totalTime, output.TotalTime, scheduleEpsilon)
+		})
+	}
+}
+
+func TestService_ScheduleRiddle_MatchesEveryPlan(t *testing.T) {
+	const maxDepth = 6
+	svc := &service{}
+	a := assert.New(t)
+
+	// quickest schedules every plan of up to maxDepth operations that reaches the goal, returning the least time
+	quickest := func(spec *ScheduleSpec) float64 {
+		riddle := &spec.RiddleSpec
+		space, tags, goal := riddle.space(), riddle.tags(), riddle.goal()
+		best := math.Inf(1)
+		var walk func(node *searchNode)
+		walk = func(node *searchNode) {
+			if goal(node.state) {
+				_, totalTime := schedule(node.operations(), tags, spec.Rates, spec.Workers)
+				best = math.Min(best, totalTime)
+				return
+			}
+			if node.depth == maxDepth {
+				return
+			}
+			for _, next := range space.expand(node) {
+				walk(next)
+			}
+		}
+		walk(&searchNode{state: riddle.initial()})
+		return best
+	}
+
+	allRates := []FlowRates{{Tap: 1, Pour: 1, Drain: 1}, {Tap: 1, Pour: 5, Drain: 5}, {Tap: 3, Pour: 1, Drain: 2}}
+	for _, capacities := range [][]int{{2, 3}, {3, 5}, {2, 3, 4}, {1, 4, 6}, {2, 5, 6}} {
+		for z := 1; z <= 6; z++ {
+			for _, rates := range allRates {
+				for workers := 1; workers <= 3; workers++ {
+					spec := &ScheduleSpec{
+						RiddleSpec: RiddleSpec{Capacities: capacities, Names: []string{"a", "b", "c"}[:len(capacities)], Z: z},
+						Rates:      rates,
+						Workers:    workers,
+					}
+					output, outputErr := svc.ScheduleRiddle(spec)
+					if outputErr != nil {
+						continue
+					}
+
+					// Longer plans aren't scheduled by quickest, so they may only be quicker
+					totalTime := quickest(spec)
+					if output.TotalSteps <= maxDepth {
+						a.InDelta(totalTime, output.TotalTime, scheduleEpsilon, "%v, z = %d, %+v and %d workers",
+							capacities, z, rates, workers)
+					} else {
+						a.LessOrEqual(output.TotalTime, totalTime+scheduleEpsilon, "%v, z = %d, %+v and %d workers",
+							capacities, z, rates, workers)
+					}
+				}
+			}
+		}
+	}
+}
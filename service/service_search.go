@@ -0,0 +1,295 @@
+package service
+
+import (
+	"container/heap"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxSearchStates is the most states a search visits before giving up, which is more than any two jugs that can be
+// searched reach
+const maxSearchStates = 250000
+
+// jugState holds the amount of water in every jug at some point of the riddle
+type jugState []int
+
+func (s jugState) key() string {
+	var sb strings.Builder
+	for i, level := range s {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.Itoa(level))
+	}
+	return sb.String()
+}
+
+func (s jugState) total() int {
+	return sumOf(s...)
+}
+
+// levels returns the amount of water in every jug, by tag
+func (s jugState) levels(tags []string) map[string]int {
+	levels := make(map[string]int, len(s))
+	for i, level := range s {
+		levels[tags[i]] = level
+	}
+	return levels
+}
+
+// searchNode is a state reached by the search, linked to the node it was reached from
+type searchNode struct {
+	state     jugState
+	parent    *searchNode
+	operation Operation
+	depth     int
+	cost      float64
+	// drawn is the amount of water drawn from the tap by every fill operation up to the node
+	drawn int
+}
+
+// searchSpace describes the jugs of a riddle and the rules every operation applied to them follows
+type searchSpace struct {
+	capacities []int
+	tags       []string
+	// supply is the most water that can be drawn from the tap, or nil when it's unlimited
+	supply *int
+	// allows checks the operations that can be applied, or is nil when every operation can
+	allows func(Operation) bool
+	// tiltable tells the jugs that can be tilted to half, or is nil when none can
+	tiltable []bool
+	// marks contains the graduation marks of every jug, sorted, or is nil when jugs are unmarked
+	marks [][]int
+}
+
+/*
+ improves tells whether a node drawing drawn reaches the state with key for the first time, according to leastDrawn.
+      When the supply is limited, a state reached again drawing strictly less water than before is worth expanding
+      too, as it may still reach states the previous nodes couldn't draw enough water for.
+*/
+func (sp *searchSpace) improves(leastDrawn map[string]int, key string, drawn int) bool {
+	least, ok := leastDrawn[key]
+	return !ok || sp.supply != nil && drawn < least
+}
+
+// operations walks back the parents of the node and returns the operations that lead to it, in order
+func (n *searchNode) operations() []Operation {
+	operations := make([]Operation, n.depth)
+	for node := n; node.parent != nil; node = node.parent {
+		operations[node.depth-1] = node.operation
+	}
+	return operations
+}
+
+/*
+ search runs a breadth-first search over the states of the jugs of space, starting at initial and applying every
+      possible fill, empty, tilt and pour operation. As every operation counts as one step, the first state accepted by goal
+      is reached through the shortest possible plan.
+
+ *searchNode: contains the node accepted by goal, or nil if there is none
+ error: is not nil when there are too many states to search
+*/
+func search(space *searchSpace, initial jugState, goal func(jugState) bool) (*searchNode, error) {
+	root := &searchNode{state: initial}
+	if goal(initial) {
+		return root, nil
+	}
+
+	leastDrawn := map[string]int{initial.key(): 0}
+	queue := []*searchNode{root}
+	visits := 1
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		for _, next := range space.expand(node) {
+			key := next.state.key()
+			if !space.improves(leastDrawn, key, next.drawn) {
+				continue
+			}
+			leastDrawn[key] = next.drawn
+			if visits++; visits > maxSearchStates {
+				return nil, errTooManyStates()
+			}
+
+			if goal(next.state) {
+				return next, nil
+			}
+			queue = append(queue, next)
+		}
+	}
+
+	return nil, nil
+}
+
+/*
+ cheapestSearch runs Dijkstra's algorithm over the states of the jugs of space, starting at initial and applying
+      every possible fill, empty and pour operation priced by cost. The first state accepted by goal that is
+      settled is reached through the cheapest possible plan, and the shortest one among equally cheap plans.
+
+ *searchNode: contains the node accepted by goal, or nil if there is none
+ error: is not nil when there are too many states to search
+*/
+func cheapestSearch(space *searchSpace, initial jugState, goal func(jugState) bool,
+	cost func(Operation) float64) (*searchNode, error) {
+	settled := map[string]int{}
+	queue := &nodeQueue{}
+	heap.Push(queue, &searchNode{state: initial})
+	visits := 0
+
+	for queue.Len() > 0 {
+		node := heap.Pop(queue).(*searchNode)
+		key := node.state.key()
+		if !space.improves(settled, key, node.drawn) {
+			continue
+		}
+		settled[key] = node.drawn
+		if visits++; visits > maxSearchStates {
+			return nil, errTooManyStates()
+		}
+
+		if goal(node.state) {
+			return node, nil
+		}
+
+		for _, next := range space.expand(node) {
+			if !space.improves(settled, next.state.key(), next.drawn) {
+				continue
+			}
+			next.operation.Cost = cost(next.operation)
+			next.cost = node.cost + next.operation.Cost
+			heap.Push(queue, next)
+		}
+	}
+
+	return nil, nil
+}
+
+func errTooManyStates() error {
+	return fmt.Errorf("there are more than %d states to search, try smaller or fewer jugs", maxSearchStates)
+}
+
+// nodeQueue is a priority queue of nodes ordered by cost, then by depth, then by insertion
+type nodeQueue struct {
+	nodes []*searchNode
+	seqs  []int
+	seq   int
+}
+
+func (q *nodeQueue) Len() int { return len(q.nodes) }
+
+func (q *nodeQueue) Less(i, j int) bool {
+	if q.nodes[i].cost != q.nodes[j].cost {
+		return q.nodes[i].cost < q.nodes[j].cost
+	}
+	if q.nodes[i].depth != q.nodes[j].depth {
+		return q.nodes[i].depth < q.nodes[j].depth
+	}
+	return q.seqs[i] < q.seqs[j]
+}
+
+func (q *nodeQueue) Swap(i, j int) {
+	q.nodes[i], q.nodes[j] = q.nodes[j], q.nodes[i]
+	q.seqs[i], q.seqs[j] = q.seqs[j], q.seqs[i]
+}
+
+func (q *nodeQueue) Push(x interface{}) {
+	q.nodes = append(q.nodes, x.(*searchNode))
+	q.seqs = append(q.seqs, q.seq)
+	q.seq++
+}
+
+func (q *nodeQueue) Pop() interface{} {
+	last := len(q.nodes) - 1
+	node := q.nodes[last]
+	q.nodes = q.nodes[:last]
+	q.seqs = q.seqs[:last]
+	return node
+}
+
+// expand returns the nodes reachable from node by applying a single operation
+func (sp *searchSpace) expand(node *searchNode) []*searchNode {
+	var nodes []*searchNode
+	capacities, tags := sp.capacities, sp.tags
+	step := node.depth + 1
+
+	next := func(state jugState, operation Operation) {
+		if sp.allows != nil && !sp.allows(operation) {
+			return
+		}
+		drawn := node.drawn
+		if operation.OperationType == operationTypeFill {
+			drawn += operation.WaterAmount
+		}
+		nodes = append(nodes, &searchNode{
+			state:     state,
+			parent:    node,
+			operation: operation,
+			depth:     step,
+			drawn:     drawn,
+		})
+	}
+
+	for i, level := range node.state {
+		if level < capacities[i] && sp.drawable(node, capacities[i]-level) {
+			state := node.copyState()
+			state[i] = capacities[i]
+			next(state, fillOperation(tags[i], capacities[i], capacities[i]-level, step))
+		}
+
+		for _, mark := range sp.marksOf(i) {
+			if mark > level && sp.drawable(node, mark-level) {
+				state := node.copyState()
+				state[i] = mark
+				next(state, markedFillOperation(tags[i], mark, mark-level, step))
+			}
+		}
+
+		if level > 0 {
+			state := node.copyState()
+			state[i] = 0
+			next(state, emptyOperation(tags[i], capacities[i], level, step))
+		}
+
+		if sp.tiltable != nil && sp.tiltable[i] && level > capacities[i]/2 {
+			state := node.copyState()
+			state[i] = capacities[i] / 2
+			next(state, tiltOperation(tags[i], capacities[i], level-capacities[i]/2, step))
+		}
+	}
+
+	for i, origin := range node.state {
+		for j, destination := range node.state {
+			if i == j || origin == 0 || destination == capacities[j] {
+				continue
+			}
+			amount := min(origin, capacities[j]-destination)
+			state := node.copyState()
+			state[i] -= amount
+			state[j] += amount
+			next(state, pourOperation(tags[i], tags[j], amount, step))
+
+			for _, pour := range sp.markedPours(node.state, i, j, amount) {
+				state := node.copyState()
+				state[i] -= pour.amount
+				state[j] += pour.amount
+				next(state, markedPourOperation(tags[i], tags[j], tags[pour.jug], pour.mark, pour.amount, step))
+			}
+		}
+	}
+
+	return nodes
+}
+
+// drawable returns whether amount can still be drawn from the tap after reaching node
+func (sp *searchSpace) drawable(node *searchNode, amount int) bool {
+	return sp.supply == nil || node.drawn+amount <= *sp.supply
+}
+
+func (n *searchNode) copyState() jugState {
+	state := make(jugState, len(n.state))
+	copy(state, n.state)
+	return state
+}
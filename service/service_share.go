@@ -0,0 +1,133 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ShareSpec describes a sharing puzzle, where water is only poured between jugs, without tap nor drain
+type ShareSpec struct {
+	// Capacities contains the capacity of every jug
+	Capacities []int `json:"capacities,omitempty"`
+	// Names contains the tag used to refer to every jug. Jugs are tagged "1", "2", ... when it's empty
+	Names []string `json:"names,omitempty"`
+	// Initial contains the amount of water in every jug before the first pour
+	Initial []int `json:"initial,omitempty"`
+	// Goal contains the amount of water some jugs must hold, by tag. Jugs missing from it can hold any amount
+	Goal map[string]int `json:"goal,omitempty"`
+}
+
+func (s *service) ShareRiddle(spec *ShareSpec) (*RiddleResponse, *AppError) {
+	if err := validateShareSpec(spec); err != nil {
+		return nil, err
+	}
+
+	tags := spec.tags()
+	indexes := map[string]int{}
+	for i, tag := range tags {
+		indexes[tag] = i
+	}
+
+	space := &searchSpace{
+		capacities: spec.Capacities,
+		tags:       tags,
+		allows: func(operation Operation) bool {
+			return operation.OperationType == operationTypePour
+		},
+	}
+	goal := &RiddleGoal{Levels: spec.Goal}
+	initial := make(jugState, len(spec.Initial))
+	copy(initial, spec.Initial)
+
+	node, searchErr := search(space, initial, goal.accepts(indexes, 0))
+	if searchErr != nil {
+		return nil, &AppError{
+			Error:   searchErr,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+	if node == nil {
+		return nil, &AppError{
+			Error:   fmt.Errorf("there is no solution to share the water into the goal with jugs with %v", spec.Capacities),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return &RiddleResponse{
+		Operations: node.operations(),
+		Jug:        node.state.describe(tags),
+		TotalSteps: node.depth,
+		Levels:     node.state.levels(tags),
+		Optimal:    true,
+	}, nil
+}
+
+func (spec *ShareSpec) tags() []string {
+	return jugTags(len(spec.Capacities), spec.Names)
+}
+
+/*
+ validateShareSpec checks the puzzle can be posed. As water is conserved, the gcd of the jugs says nothing about the
+      amounts that can be shared, but the goal can't hold more water than the jugs do.
+*/
+func validateShareSpec(spec *ShareSpec) *AppError {
+	if len(spec.Capacities) < 2 {
+		return &AppError{
+			Error:   errors.New("at least two jugs are required"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if len(spec.Names) > 0 {
+		if err := validateJugNames(spec.Names, len(spec.Capacities)); err != nil {
+			return &AppError{
+				Error:   err,
+				Message: "invalid parameters",
+				Code:    http.StatusBadRequest,
+			}
+		}
+	}
+
+	tags := spec.tags()
+	if err := validateInitialLevels(spec.Initial, spec.Capacities, tags); err != nil {
+		return &AppError{
+			Error:   err,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if len(spec.Goal) == 0 {
+		return &AppError{
+			Error:   errors.New("goal needs the levels of some jugs"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if err := validateGoalLevels(spec.Goal, spec.Capacities, tags); err != nil {
+		return &AppError{
+			Error:   err,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	water, goal := sumOf(spec.Initial...), 0
+	for _, level := range spec.Goal {
+		goal += level
+	}
+	if goal > water || (len(spec.Goal) == len(spec.Capacities) && goal != water) {
+		return &AppError{
+			Error:   fmt.Errorf("the goal holds %d units of water but jugs hold %d", goal, water),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return nil
+}
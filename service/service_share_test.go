@@ -0,0 +1,146 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_ShareRiddle(t *testing.T) {
+	type want struct {
+		jug        string
+		totalSteps int
+		levels     map[string]int
+		outputErr  *AppError
+	}
+	tests := []struct {
+		name string
+		spec *ShareSpec
+		want want
+	}{
+		{
+			name: "a single jug",
+			spec: &ShareSpec{
+				Capacities: []int{8},
+				Initial:    []int{8},
+				Goal:       map[string]int{"1": 4},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("at least two jugs are required"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "initial levels are missing",
+			spec: &ShareSpec{
+				Capacities: []int{8, 5, 3},
+				Goal:       map[string]int{"1": 4},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("expected 3 initial levels but got 0"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "goal is missing",
+			spec: &ShareSpec{
+				Capacities: []int{8, 5, 3},
+				Initial:    []int{8, 0, 0},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("goal needs the levels of some jugs"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "goal level is bigger than its jug",
+			spec: &ShareSpec{
+				Capacities: []int{8, 5, 3},
+				Initial:    []int{8, 0, 0},
+				Goal:       map[string]int{"3": 4},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("level of jug 3 must be between 0 and 3"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "goal doesn't hold the water of the jugs",
+			spec: &ShareSpec{
+				Capacities: []int{8, 5, 3},
+				Initial:    []int{8, 0, 0},
+				Goal:       map[string]int{"1": 4, "2": 3, "3": 0},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("the goal holds 7 units of water but jugs hold 8"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "goal can't be reached by pouring",
+			spec: &ShareSpec{
+				Capacities: []int{4, 6},
+				Initial:    []int{4, 0},
+				Goal:       map[string]int{"1": 1},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("there is no solution to share the water into the goal with jugs with %v", []int{4, 6}),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "success sharing 8 in halves with jugs with 8, 5 and 3",
+			spec: &ShareSpec{
+				Capacities: []int{8, 5, 3},
+				Names:      []string{"a", "b", "c"},
+				Initial:    []int{8, 0, 0},
+				Goal:       map[string]int{"a": 4, "b": 4},
+			},
+			want: want{
+				jug:        "a=4, b=4, c=0",
+				totalSteps: 7,
+				levels:     map[string]int{"a": 4, "b": 4, "c": 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &service{}
+			output, outputErr := svc.ShareRiddle(tt.spec)
+
+			a := assert.New(t)
+			a.Equal(tt.want.outputErr, outputErr)
+			if tt.want.outputErr != nil {
+				return
+			}
+
+			a.Equal(tt.want.jug, output.Jug)
+			a.Equal(tt.want.totalSteps, output.TotalSteps)
+			a.Equal(tt.want.levels, output.Levels)
+			for _, operation := range output.Operations {
+				a.Equal(operationTypePour, operation.OperationType)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"strconv"
+)
+
+// SolutionsResponse counts every distinct shortest plan of a riddle
+type SolutionsResponse struct {
+	// Count is the number of distinct shortest plans, computed exactly even when there are too many to list
+	Count      *big.Int         `json:"count"`
+	TotalSteps int              `json:"total_steps"`
+	Plans      []RiddleResponse `json:"plans,omitempty"`
+}
+
+// countedState is a node reached by the search together with the number of shortest plans that reach it
+type countedState struct {
+	node  *searchNode
+	count *big.Int
+	// edges contains every operation that reaches the state from the previous depth
+	edges []countedEdge
+}
+
+type countedEdge struct {
+	from      *countedState
+	operation Operation
+}
+
+func (s *service) CountRiddle(spec *RiddleSpec, limit int) (*SolutionsResponse, *AppError) {
+	if err := validateRiddleSpec(spec); err != nil {
+		return nil, err
+	}
+
+	if spec.Costs != nil {
+		return nil, &AppError{
+			Error:   errors.New("costs can't be used when counting the shortest plans"),
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	tags := spec.tags()
+	goals, err := countSearch(spec.space(), spec.initial(), spec.goal())
+	if err != nil {
+		return nil, &AppError{
+			Error:   err,
+			Message: "invalid parameters",
+			Code:    http.StatusBadRequest,
+		}
+	}
+	if len(goals) == 0 {
+		return nil, spec.unsolvable()
+	}
+
+	response := &SolutionsResponse{
+		Count:      new(big.Int),
+		TotalSteps: goals[0].node.depth,
+	}
+	for _, goal := range goals {
+		response.Count.Add(response.Count, goal.count)
+	}
+
+	operations := make([]Operation, response.TotalSteps)
+	for _, goal := range goals {
+		if len(response.Plans) >= limit {
+			break
+		}
+		goal.plans(operations, func(plan []Operation) bool {
+			riddle := RiddleResponse{
+				Operations: make([]Operation, len(plan)),
+				Jug:        spec.jug(goal.node.state, tags),
+				TotalSteps: len(plan),
+				Levels:     goal.node.state.levels(tags),
+				Optimal:    true,
+			}
+			copy(riddle.Operations, plan)
+			response.Plans = append(response.Plans, riddle)
+			return len(response.Plans) < limit
+		})
+	}
+
+	return response, nil
+}
+
+/*
+ countSearch runs a breadth-first search like search does, one depth at a time, counting how many plans reach every
+      state through the shortest possible plan. The count of a state is the sum of the counts of every state at the
+      previous depth it's reached from, so every plan is counted without being generated.
+
+ []*countedState: contains the states accepted by goal at the first depth any of them is reached, in the order they
+      were reached, or nil if there is none
+ error: is not nil when there are too many states to search
+*/
+func countSearch(space *searchSpace, initial jugState, goal func(jugState) bool) ([]*countedState, error) {
+	root := &countedState{node: &searchNode{state: initial}, count: big.NewInt(1)}
+	if goal(initial) {
+		return []*countedState{root}, nil
+	}
+
+	// Plans reaching a state drawing different amounts of water may go on differently, so they're counted apart
+	key := func(node *searchNode) string {
+		if space.supply == nil {
+			return node.state.key()
+		}
+		return node.state.key() + "/" + strconv.Itoa(node.drawn)
+	}
+
+	reached := map[string]*countedState{key(root.node): root}
+	depth := []*countedState{root}
+
+	for len(depth) > 0 {
+		var next, goals []*countedState
+
+		for _, current := range depth {
+			for _, node := range space.expand(current.node) {
+				nodeKey := key(node)
+				state, ok := reached[nodeKey]
+				if !ok {
+					state = &countedState{node: node, count: new(big.Int)}
+					reached[nodeKey] = state
+					if len(reached) > maxSearchStates {
+						return nil, errTooManyStates()
+					}
+					next = append(next, state)
+					if goal(node.state) {
+						goals = append(goals, state)
+					}
+				}
+
+				// Reaching a state again at a deeper depth is never part of a shortest plan
+				if state.node.depth != node.depth {
+					continue
+				}
+				state.count.Add(state.count, current.count)
+				state.edges = append(state.edges, countedEdge{from: current, operation: node.operation})
+			}
+		}
+
+		if len(goals) > 0 {
+			return goals, nil
+		}
+		depth = next
+	}
+
+	return nil, nil
+}
+
+// plans walks back every edge of the state, passing each shortest plan that reaches it to emit until it returns false
+func (c *countedState) plans(operations []Operation, emit func([]Operation) bool) bool {
+	if c.node.depth == 0 {
+		return emit(operations)
+	}
+
+	for _, edge := range c.edges {
+		operations[c.node.depth-1] = edge.operation
+		if !edge.from.plans(operations, emit) {
+			return false
+		}
+	}
+	return true
+}
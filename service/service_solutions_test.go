@@ -0,0 +1,158 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_CountRiddle(t *testing.T) {
+	type want struct {
+		count      int64
+		totalSteps int
+		plans      int
+		outputErr  *AppError
+	}
+	tests := []struct {
+		name  string
+		spec  *RiddleSpec
+		limit int
+		want  want
+	}{
+		{
+			name: "costs can't be counted",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Z:          4,
+				Costs:      map[OperationType]OperationCost{},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("costs can't be used when counting the shortest plans"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "gcd of every jug doesn't divide z",
+			spec: &RiddleSpec{
+				Capacities: []int{2, 4},
+				Z:          3,
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("there is no solution to measure %d with jugs with %v", 3, []int{2, 4}),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "success with a single shortest plan",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Z:          4,
+			},
+			limit: 10,
+			want: want{
+				count:      1,
+				totalSteps: 6,
+				plans:      1,
+			},
+		},
+		{
+			name: "success with several shortest plans",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5, 7},
+				Z:          1,
+			},
+			limit: 10,
+			want: want{
+				count:      9,
+				totalSteps: 4,
+				plans:      9,
+			},
+		},
+		{
+			name: "success counting without listing",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Z:          8,
+				Total:      true,
+			},
+			want: want{
+				count:      2,
+				totalSteps: 2,
+			},
+		},
+		{
+			name: "success with more plans than the limit",
+			spec: &RiddleSpec{
+				Capacities: []int{1, 2, 3, 4, 5, 6},
+				Z:          21,
+				Total:      true,
+			},
+			limit: 5,
+			want: want{
+				count:      720,
+				totalSteps: 6,
+				plans:      5,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &service{}
+			output, outputErr := svc.CountRiddle(tt.spec, tt.limit)
+
+			a := assert.New(t)
+			a.Equal(tt.want.outputErr, outputErr)
+			if tt.want.outputErr != nil {
+				return
+			}
+
+			a.Equal(big.NewInt(tt.want.count), output.Count)
+			a.Equal(tt.want.totalSteps, output.TotalSteps)
+			a.Len(output.Plans, tt.want.plans)
+
+			seen := map[string]bool{}
+			for _, plan := range output.Plans {
+				a.Equal(tt.want.totalSteps, plan.TotalSteps)
+				a.True(plan.Optimal)
+
+				key := fmt.Sprintf("%+v", describe(plan.Operations))
+				a.False(seen[key], "plan %s is repeated", key)
+				seen[key] = true
+			}
+		})
+	}
+}
+
+func TestService_CountRiddle_MatchesSolveRiddle(t *testing.T) {
+	svc := &service{}
+	spec := &RiddleSpec{
+		Capacities: []int{3, 5, 7},
+		Z:          1,
+	}
+
+	solution, _ := svc.SolveRiddle(spec)
+	output, outputErr := svc.CountRiddle(spec, 1)
+
+	a := assert.New(t)
+	a.Nil(outputErr)
+	a.Equal(*solution, output.Plans[0])
+}
+
+// describe returns the description of every operation, which tells them apart
+func describe(operations []Operation) []string {
+	descriptions := make([]string, len(operations))
+	for i, operation := range operations {
+		descriptions[i] = operation.Description
+	}
+	return descriptions
+}
@@ -0,0 +1,25 @@
+package service
+
+import "net/http"
+
+func (s *service) StreamRiddle(x, y, z int, emit func(Operation) error) (*RiddleResponse, *AppError) {
+	// The oracle tells which of both scenarios is shorter before generating any operation
+	oracle, appErr := getOracle(x, y, z)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	jug, err := s.pourEach(oracle.jug1Cap, oracle.jug1Tag, oracle.jug2Cap, oracle.jug2Tag, z, emit)
+	if err != nil {
+		return nil, &AppError{
+			Error:   err,
+			Message: "error streaming operations",
+			Code:    http.StatusInternalServerError,
+		}
+	}
+
+	return &RiddleResponse{
+		Jug:        jug,
+		TotalSteps: oracle.totalSteps(),
+	}, nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_StreamRiddle(t *testing.T) {
+	type args struct {
+		x int
+		y int
+		z int
+	}
+	tests := []struct {
+		name      string
+		args      args
+		emitErr   error
+		outputErr *AppError
+	}{
+		{
+			name: "z is bigger than x and y",
+			args: args{
+				x: 1,
+				y: 2,
+				z: 3,
+			},
+			outputErr: &AppError{
+				Error:   fmt.Errorf("can't measure %d if it's bigger than jugs for %d and %d", 3, 1, 2),
+				Message: "invalid parameters",
+				Code:    http.StatusBadRequest,
+			},
+		},
+		{
+			name: "error emitting operations",
+			args: args{
+				x: 3,
+				y: 5,
+				z: 4,
+			},
+			emitErr: errors.New("connection closed"),
+			outputErr: &AppError{
+				Error:   errors.New("connection closed"),
+				Message: "error streaming operations",
+				Code:    http.StatusInternalServerError,
+			},
+		},
+		{
+			name: "success with x = 3, y = 5 and z = 4",
+			args: args{
+				x: 3,
+				y: 5,
+				z: 4,
+			},
+		},
+		{
+			name: "success with y = 3, x = 5 and z = 4",
+			args: args{
+				x: 5,
+				y: 3,
+				z: 4,
+			},
+		},
+		{
+			name: "success with x = 4, y = 9 and z = 6",
+			args: args{
+				x: 4,
+				y: 9,
+				z: 6,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &service{}
+
+			var operations []Operation
+			output, outputErr := svc.StreamRiddle(tt.args.x, tt.args.y, tt.args.z, func(operation Operation) error {
+				operations = append(operations, operation)
+				return tt.emitErr
+			})
+
+			a := assert.New(t)
+			a.Equal(tt.outputErr, outputErr)
+			if tt.outputErr != nil {
+				a.Nil(output)
+				return
+			}
+
+			want, _ := svc.Riddle(tt.args.x, tt.args.y, tt.args.z)
+			a.Equal(want.Operations, operations)
+			a.Equal(&RiddleResponse{Jug: want.Jug, TotalSteps: want.TotalSteps}, output)
+		})
+	}
+}
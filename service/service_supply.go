@@ -0,0 +1,24 @@
+package service
+
+/*
+ neededSupply runs the cheapest search over the jugs of the riddle with an unlimited tap, where the only operation
+      that costs is filling a jug, by the amount of water it draws.
+
+ int: contains the least water drawn from the tap by any plan that solves the riddle
+ bool: is false when the riddle can't be solved even with an unlimited tap, or it has too many states to tell
+*/
+func (spec *RiddleSpec) neededSupply() (int, bool) {
+	space := spec.space()
+	space.supply = nil
+
+	node, err := cheapestSearch(space, spec.initial(), spec.goal(), func(operation Operation) float64 {
+		if operation.OperationType == operationTypeFill {
+			return float64(operation.WaterAmount)
+		}
+		return 0
+	})
+	if err != nil || node == nil {
+		return 0, false
+	}
+	return node.drawn, true
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_SolveRiddle_Supply(t *testing.T) {
+	type want struct {
+		totalSteps int
+		levels     map[string]int
+		outputErr  *AppError
+	}
+	tests := []struct {
+		name string
+		spec *RiddleSpec
+		want want
+	}{
+		{
+			name: "supply is negative",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Z:          4,
+				Supply:     aws.Int(-1),
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("supply can't be negative"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "supply is not enough",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Z:          4,
+				Supply:     aws.Int(8),
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("there is no solution drawing at most 8 units of water, as at least 9 are needed"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "no solution with a supply far bigger than the jugs",
+			spec: &RiddleSpec{
+				Capacities:   []int{3, 5},
+				Names:        []string{xJugTag, yJugTag},
+				Z:            4,
+				Supply:       aws.Int(1000000000),
+				Restrictions: &Restrictions{Operations: []OperationType{operationTypePour}},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("there is no solution with jugs with [3 5] without the restricted operations"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "success with supply for the shortest plan",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Names:      []string{xJugTag, yJugTag},
+				Z:          4,
+				Supply:     aws.Int(10),
+			},
+			want: want{
+				totalSteps: 6,
+				levels:     map[string]int{xJugTag: 3, yJugTag: 4},
+			},
+		},
+		{
+			name: "success with a longer plan drawing less water",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Names:      []string{xJugTag, yJugTag},
+				Z:          4,
+				Supply:     aws.Int(9),
+			},
+			want: want{
+				totalSteps: 8,
+				levels:     map[string]int{xJugTag: 0, yJugTag: 4},
+			},
+		},
+		{
+			name: "success without drawing water",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 5},
+				Names:      []string{xJugTag, yJugTag},
+				Initial:    []int{3, 4},
+				Z:          2,
+				Supply:     aws.Int(0),
+			},
+			want: want{
+				totalSteps: 1,
+				levels:     map[string]int{xJugTag: 2, yJugTag: 5},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &service{}
+			output, outputErr := svc.SolveRiddle(tt.spec)
+
+			a := assert.New(t)
+			a.Equal(tt.want.outputErr, outputErr)
+			if tt.want.outputErr != nil {
+				return
+			}
+
+			a.Equal(tt.want.totalSteps, output.TotalSteps)
+			a.Equal(tt.want.levels, output.Levels)
+		})
+	}
+}
+
+func TestService_ParetoRiddle_Supply(t *testing.T) {
+	svc := &service{}
+	output, outputErr := svc.ParetoRiddle(&RiddleSpec{
+		Capacities: []int{3, 5},
+		Z:          4,
+		Supply:     aws.Int(9),
+	})
+
+	a := assert.New(t)
+	a.Nil(outputErr)
+	a.Len(output.Plans, 1)
+	a.Equal(8, output.Plans[0].TotalSteps)
+	a.Equal(9, output.Plans[0].WaterUsed)
+}
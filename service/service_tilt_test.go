@@ -0,0 +1,163 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_SolveRiddle_Tilt(t *testing.T) {
+	type want struct {
+		output    *RiddleResponse
+		outputErr *AppError
+	}
+	tests := []struct {
+		name string
+		spec *RiddleSpec
+		want want
+	}{
+		{
+			name: "tilted jug is unknown",
+			spec: &RiddleSpec{
+				Capacities: []int{6, 8},
+				Names:      []string{xJugTag, yJugTag},
+				Z:          3,
+				Tilt:       []string{"w"},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("unknown jug w"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "tilted jug has an odd capacity",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 8},
+				Names:      []string{xJugTag, yJugTag},
+				Z:          4,
+				Tilt:       []string{xJugTag},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("jug %s can't be tilted to half, as its capacity 3 is odd", xJugTag),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "gcd of every jug and half doesn't divide z",
+			spec: &RiddleSpec{
+				Capacities: []int{4, 8},
+				Names:      []string{xJugTag, yJugTag},
+				Z:          1,
+				Tilt:       []string{xJugTag},
+			},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("there is no solution to measure %d with jugs with %v", 1, []int{4, 8}),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "success with half of a jug that can't be measured otherwise",
+			spec: &RiddleSpec{
+				Capacities: []int{6, 8},
+				Names:      []string{xJugTag, yJugTag},
+				Z:          3,
+				Tilt:       []string{xJugTag},
+			},
+			want: want{
+				output: &RiddleResponse{
+					Operations: []Operation{
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String(xJugTag),
+							WaterAmount:   6,
+							Step:          1,
+							Description:   fmt.Sprintf("filling jug %s with 6 capacity", xJugTag),
+						},
+						{
+							OperationType: operationTypeTilt,
+							Jug:           aws.String(xJugTag),
+							WaterAmount:   3,
+							Step:          2,
+							Description:   fmt.Sprintf("tilting jug %s with 6 capacity to half", xJugTag),
+						},
+					},
+					Jug:        xJugTag,
+					TotalSteps: 2,
+					Levels:     map[string]int{xJugTag: 3, yJugTag: 0},
+					Optimal:    true,
+				},
+			},
+		},
+		{
+			name: "success with a shorter plan tilting a jug",
+			spec: &RiddleSpec{
+				Capacities: []int{3, 8},
+				Names:      []string{xJugTag, yJugTag},
+				Z:          4,
+				Tilt:       []string{yJugTag},
+			},
+			want: want{
+				output: &RiddleResponse{
+					Operations: []Operation{
+						{
+							OperationType: operationTypeFill,
+							Jug:           aws.String(yJugTag),
+							WaterAmount:   8,
+							Step:          1,
+							Description:   fmt.Sprintf("filling jug %s with 8 capacity", yJugTag),
+						},
+						{
+							OperationType: operationTypeTilt,
+							Jug:           aws.String(yJugTag),
+							WaterAmount:   4,
+							Step:          2,
+							Description:   fmt.Sprintf("tilting jug %s with 8 capacity to half", yJugTag),
+						},
+					},
+					Jug:        yJugTag,
+					TotalSteps: 2,
+					Levels:     map[string]int{xJugTag: 0, yJugTag: 4},
+					Optimal:    true,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &service{}
+			output, outputErr := svc.SolveRiddle(tt.spec)
+
+			a := assert.New(t)
+			a.Equal(tt.want.output, output)
+			a.Equal(tt.want.outputErr, outputErr)
+		})
+	}
+}
+
+func TestService_ParetoRiddle_Tilt(t *testing.T) {
+	svc := &service{}
+	output, outputErr := svc.ParetoRiddle(&RiddleSpec{
+		Capacities: []int{3, 8},
+		Z:          4,
+		Tilt:       []string{"2"},
+	})
+
+	a := assert.New(t)
+	a.Nil(outputErr)
+	a.Equal(2, output.Plans[0].TotalSteps)
+	a.Equal(8, output.Plans[0].WaterUsed)
+	a.Equal(4, output.Plans[0].WaterWasted)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+const (
+	// tapNode and drainNode are the ends of the connections that fill and empty jugs
+	tapNode   = "tap"
+	drainNode = "drain"
+)
+
+// Topology describes the one-way connections water can flow through. Jugs can only be filled from the tap, emptied
+// into the drain or poured into another jug when they are connected to them
+type Topology struct {
+	Connections []Connection `json:"connections,omitempty"`
+}
+
+// Connection is a one-way pipe from From to To, which are the tags of jugs, the tap or the drain
+type Connection struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// allows returns the check for the operations that flow through a connection. Tilting a jug pours the water over its
+// rim, so it doesn't need any
+func (t *Topology) allows() func(Operation) bool {
+	connections := map[Connection]bool{}
+	for _, connection := range t.Connections {
+		connections[connection] = true
+	}
+
+	return func(operation Operation) bool {
+		switch operation.OperationType {
+		case operationTypeFill:
+			return connections[Connection{From: tapNode, To: aws.StringValue(operation.Jug)}]
+		case operationTypeEmpty:
+			return connections[Connection{From: aws.StringValue(operation.Jug), To: drainNode}]
+		case operationTypePour:
+			return connections[Connection{
+				From: aws.StringValue(operation.JugOrigin),
+				To:   aws.StringValue(operation.JugDestination),
+			}]
+		default:
+			return true
+		}
+	}
+}
+
+// allOf returns the check for the operations that every check allows, ignoring the missing ones
+func allOf(checks ...func(Operation) bool) func(Operation) bool {
+	var present []func(Operation) bool
+	for _, check := range checks {
+		if check != nil {
+			present = append(present, check)
+		}
+	}
+	if len(present) == 0 {
+		return nil
+	}
+
+	return func(operation Operation) bool {
+		for _, check := range present {
+			if !check(operation) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func validateTopology(topology *Topology, tags []string) error {
+	for _, tag := range tags {
+		if tag == tapNode || tag == drainNode {
+			return fmt.Errorf("jug name %s is reserved when there are connections", tag)
+		}
+	}
+
+	for _, connection := range topology.Connections {
+		if connection.From == drainNode {
+			return errors.New("drain can only be the destination of a connection")
+		}
+		if connection.To == tapNode {
+			return errors.New("tap can only be the origin of a connection")
+		}
+		if connection.From == tapNode && connection.To == drainNode {
+			return errors.New("tap can't be connected to the drain")
+		}
+		if connection.From != tapNode && indexOf(tags, connection.From) < 0 {
+			return fmt.Errorf("unknown jug %s", connection.From)
+		}
+		if connection.To != drainNode && indexOf(tags, connection.To) < 0 {
+			return fmt.Errorf("unknown jug %s", connection.To)
+		}
+		if connection.From == connection.To {
+			return fmt.Errorf("jug %s can't pour into itself", connection.From)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_SolveRiddle_Topology(t *testing.T) {
+	type want struct {
+		totalSteps int
+		outputErr  *AppError
+	}
+	tests := []struct {
+		name        string
+		names       []string
+		connections []Connection
+		want        want
+	}{
+		{
+			name:        "jug named as the tap",
+			names:       []string{tapNode, yJugTag},
+			connections: []Connection{{From: tapNode, To: yJugTag}},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("jug name %s is reserved when there are connections", tapNode),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name:        "connection from the drain",
+			connections: []Connection{{From: drainNode, To: xJugTag}},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("drain can only be the destination of a connection"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name:        "connection to the tap",
+			connections: []Connection{{From: xJugTag, To: tapNode}},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("tap can only be the origin of a connection"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name:        "connection to an unknown jug",
+			connections: []Connection{{From: tapNode, To: "w"}},
+			want: want{
+				outputErr: &AppError{
+					Error:   errors.New("unknown jug w"),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name:        "no solution without a drain",
+			connections: []Connection{{From: tapNode, To: xJugTag}, {From: xJugTag, To: yJugTag}},
+			want: want{
+				outputErr: &AppError{
+					Error:   fmt.Errorf("there is no solution with jugs with %v along their connections", []int{3, 5}),
+					Message: "invalid parameters",
+					Code:    http.StatusBadRequest,
+				},
+			},
+		},
+		{
+			name: "success along the pipes of the shortest plan",
+			connections: []Connection{
+				{From: tapNode, To: yJugTag},
+				{From: yJugTag, To: xJugTag},
+				{From: xJugTag, To: drainNode},
+			},
+			want: want{
+				totalSteps: 6,
+			},
+		},
+		{
+			name: "success along the pipes of a longer plan",
+			connections: []Connection{
+				{From: tapNode, To: xJugTag},
+				{From: xJugTag, To: yJugTag},
+				{From: yJugTag, To: drainNode},
+			},
+			want: want{
+				totalSteps: 8,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names := tt.names
+			if names == nil {
+				names = []string{xJugTag, yJugTag}
+			}
+			topology := &Topology{Connections: tt.connections}
+
+			svc := &service{}
+			output, outputErr := svc.SolveRiddle(&RiddleSpec{
+				Capacities: []int{3, 5},
+				Names:      names,
+				Z:          4,
+				Topology:   topology,
+			})
+
+			a := assert.New(t)
+			a.Equal(tt.want.outputErr, outputErr)
+			if tt.want.outputErr != nil {
+				return
+			}
+
+			a.Equal(tt.want.totalSteps, output.TotalSteps)
+			allows := topology.allows()
+			for _, operation := range output.Operations {
+				a.True(allows(operation), "operation %s has no connection", operation.Description)
+			}
+		})
+	}
+}
+
+func TestService_SolveRiddle_TopologyWithUnreachableGoal(t *testing.T) {
+	svc := &service{}
+	output, outputErr := svc.SolveRiddle(&RiddleSpec{
+		Capacities: []int{3, 5},
+		Names:      []string{xJugTag, yJugTag},
+		Goal:       &RiddleGoal{Levels: map[string]int{xJugTag: 1, yJugTag: 1}},
+		Topology:   &Topology{Connections: []Connection{{From: tapNode, To: xJugTag}, {From: xJugTag, To: yJugTag}}},
+	})
+
+	// Jugs connected to everything always leave one of them empty or full, so they can't reach the goal either
+	a := assert.New(t)
+	a.Nil(output)
+	a.Equal(&AppError{
+		Error:   fmt.Errorf("there is no solution to reach the goal with jugs with %v", []int{3, 5}),
+		Message: "invalid parameters",
+		Code:    http.StatusBadRequest,
+	}, outputErr)
+}